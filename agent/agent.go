@@ -0,0 +1,81 @@
+// Package agent provides bots that play the Centipede engine. An Agent looks
+// at the game each tick and returns the actions to apply before the next
+// Step; the balance simulator and the demo mode both drive games this way.
+package agent
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Agent decides what to do each tick. Act must treat g as read only - agents
+// that want to experiment work on g.Clone(). Agents keep their own state and
+// randomness, so use one agent per game.
+type Agent interface {
+	Act(g *engine.Game) []engine.Action
+}
+
+// Options tunes the built-in agents. Each agent reads only the fields it
+// needs.
+type Options struct {
+	DodgeRange    int     `json:"dodgeRange"`    // Heuristic: rows from the bottom that trigger panic dodging
+	ShootChance   float64 `json:"shootChance"`   // Heuristic: chance to shoot at a lined up target
+	Depth         int     `json:"depth"`         // Lookahead: ticks simulated per candidate move
+	ReactionTicks int     `json:"reactionTicks"` // Human: delay between seeing and acting
+	ErrorRate     float64 `json:"errorRate"`     // Human: chance a decision comes out wrong
+}
+
+// DefaultOptions matches the original balance harness AI
+func DefaultOptions() Options {
+	return Options{
+		DodgeRange:    5,
+		ShootChance:   0.7,
+		Depth:         8,
+		ReactionTicks: 4, // ~200ms at 50ms ticks
+		ErrorRate:     0.1,
+	}
+}
+
+// constructors maps agent names to their constructors
+var constructors = map[string]func(seed int64, opts Options) Agent{
+	"random":    func(seed int64, opts Options) Agent { return NewRandom(seed) },
+	"heuristic": func(seed int64, opts Options) Agent { return NewHeuristic(seed, opts) },
+	"lookahead": func(seed int64, opts Options) Agent { return NewLookahead(seed, opts) },
+	"human":     func(seed int64, opts Options) Agent { return NewHuman(seed, opts) },
+}
+
+// Names lists the built-in agents
+func Names() []string {
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates a built-in agent by name. The same seed and options always
+// make the same decisions for the same game.
+func New(name string, seed int64, opts Options) (Agent, error) {
+	newAgent, ok := constructors[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown agent %q", name)
+	}
+	return newAgent(seed, opts), nil
+}
+
+// Play applies an agent's actions for this tick to g
+func Play(a Agent, g *engine.Game) {
+	for _, action := range a.Act(g) {
+		g.Apply(action)
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"math/rand"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Heuristic is the original balance harness AI: dodge when the centipede or
+// a spider gets close, otherwise line up on heads, flies and segments. It
+// reacts instantly and never misses a dodge, so it plays better than most
+// people.
+type Heuristic struct {
+	rng         *rand.Rand
+	dodgeRange  int
+	shootChance float64
+}
+
+func NewHeuristic(seed int64, opts Options) *Heuristic {
+	return &Heuristic{
+		rng:         rand.New(rand.NewSource(seed)),
+		dodgeRange:  opts.DodgeRange,
+		shootChance: opts.ShootChance,
+	}
+}
+
+func (h *Heuristic) Act(g *engine.Game) []engine.Action {
+	if h.inDanger(g) {
+		// PANIC MODE: Focus on dodging
+		actions := panicDodge(g)
+		if h.rng.Float64() < 0.9 { // Shoot more aggressively
+			actions = append(actions, engine.ActionShoot)
+		}
+		return actions
+	}
+	// NORMAL MODE: Balanced strategy
+	return h.normalPlay(g)
+}
+
+// inDanger reports a centipede within dodgeRange rows of the bottom or a
+// spider closing in
+func (h *Heuristic) inDanger(g *engine.Game) bool {
+	for _, c := range g.Centipedes() {
+		for _, seg := range c.Segments {
+			if seg.Pos.Y >= g.Height()-h.dodgeRange {
+				return true
+			}
+		}
+	}
+	return SpiderNear(g)
+}
+
+// SpiderNear reports an active spider within 3 cells of the player
+func SpiderNear(g *engine.Game) bool {
+	for _, s := range g.Spiders() {
+		if s.Active && abs(s.Pos.X-g.Player().X) <= 3 && abs(s.Pos.Y-g.Player().Y) <= 3 {
+			return true
+		}
+	}
+	return false
+}
+
+// panicDodge moves away from the nearest threat
+func panicDodge(g *engine.Game) []engine.Action {
+	// Find nearest threat
+	nearestDist := 999
+	nearestX := -1
+
+	for _, c := range g.Centipedes() {
+		for _, seg := range c.Segments {
+			if seg.Pos.Y >= g.Height()-10 {
+				dist := abs(seg.Pos.X - g.Player().X)
+				if dist < nearestDist {
+					nearestDist = dist
+					nearestX = seg.Pos.X
+				}
+			}
+		}
+	}
+
+	// Spiders are the most immediate threat in the player zone
+	for _, s := range g.Spiders() {
+		if !s.Active {
+			continue
+		}
+		dist := abs(s.Pos.X-g.Player().X) + abs(s.Pos.Y-g.Player().Y)
+		if dist < nearestDist {
+			nearestDist = dist
+			nearestX = s.Pos.X
+		}
+	}
+
+	var actions []engine.Action
+	if nearestX != -1 {
+		// Move away from threat
+		if g.Player().X < nearestX {
+			actions = append(actions, engine.ActionLeft)
+		} else if g.Player().X > nearestX {
+			actions = append(actions, engine.ActionRight)
+		}
+
+		// Try to move up if possible
+		if g.Player().Y > g.PlayerZoneTop() {
+			actions = append(actions, engine.ActionUp)
+		}
+	}
+	return actions
+}
+
+// normalPlay balances offense and defense
+func (h *Heuristic) normalPlay(g *engine.Game) []engine.Action {
+	// Target priority: Head > Flies > Body segments
+	targetX := -1
+	targetValue := 0
+
+	// Look for heads
+	for _, c := range g.Centipedes() {
+		head := c.Segments[0]
+		if head.Pos.X == g.Player().X {
+			if targetValue < 100 {
+				targetX = head.Pos.X
+				targetValue = 100
+			}
+		}
+	}
+
+	// Look for flies
+	for _, fly := range g.Flies() {
+		if fly.Active && abs(fly.Pos.X-g.Player().X) < 3 {
+			if targetValue < 50 {
+				targetX = fly.Pos.X
+				targetValue = 50
+			}
+		}
+	}
+
+	// Look for any segment above us
+	if targetValue == 0 {
+	search:
+		for _, c := range g.Centipedes() {
+			for _, seg := range c.Segments {
+				if seg.Pos.X == g.Player().X {
+					targetX = seg.Pos.X
+					targetValue = 10
+					break search
+				}
+			}
+		}
+	}
+
+	var actions []engine.Action
+	// Move toward target or hunt
+	if targetValue > 0 {
+		if g.Player().X < targetX {
+			actions = append(actions, engine.ActionRight)
+		} else if g.Player().X > targetX {
+			actions = append(actions, engine.ActionLeft)
+		}
+
+		// Shoot if aligned
+		if h.rng.Float64() < h.shootChance {
+			actions = append(actions, engine.ActionShoot)
+		}
+	} else {
+		// Hunt mode - random walk with shooting
+		if h.rng.Float64() < 0.3 {
+			if h.rng.Float64() < 0.5 {
+				actions = append(actions, engine.ActionRight)
+			} else {
+				actions = append(actions, engine.ActionLeft)
+			}
+		}
+		if h.rng.Float64() < 0.4 {
+			actions = append(actions, engine.ActionShoot)
+		}
+	}
+	return actions
+}
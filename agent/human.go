@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"math/rand"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Human plays like a person: it decides with the heuristic, but each
+// decision only reaches the controls after a reaction delay, and some
+// decisions come out wrong - a move in the wrong direction, a missed
+// shot. Balance numbers from this agent are closer to real players than
+// the heuristic's near perfect play.
+type Human struct {
+	rng       *rand.Rand
+	brain     *Heuristic
+	errorRate float64
+	pending   [][]engine.Action // Decisions waiting out the reaction delay
+}
+
+func NewHuman(seed int64, opts Options) *Human {
+	delay := opts.ReactionTicks
+	if delay < 0 {
+		delay = 0
+	}
+	return &Human{
+		// The heuristic gets its own stream so mistakes don't change its choices
+		rng:       rand.New(rand.NewSource(seed ^ 0x5eed)),
+		brain:     NewHeuristic(seed, opts),
+		errorRate: opts.ErrorRate,
+		pending:   make([][]engine.Action, delay),
+	}
+}
+
+func (h *Human) Act(g *engine.Game) []engine.Action {
+	h.pending = append(h.pending, h.fumble(h.brain.Act(g)))
+	actions := h.pending[0]
+	h.pending = h.pending[1:]
+	return actions
+}
+
+// fumble applies the error rate to one decision
+func (h *Human) fumble(actions []engine.Action) []engine.Action {
+	out := make([]engine.Action, 0, len(actions))
+	for _, a := range actions {
+		if h.rng.Float64() >= h.errorRate {
+			out = append(out, a)
+			continue
+		}
+		switch a {
+		case engine.ActionShoot:
+			// Missed the button
+		case engine.ActionLeft:
+			out = append(out, engine.ActionRight)
+		case engine.ActionRight:
+			out = append(out, engine.ActionLeft)
+		case engine.ActionUp:
+			out = append(out, engine.ActionDown)
+		case engine.ActionDown:
+			out = append(out, engine.ActionUp)
+		}
+	}
+	return out
+}
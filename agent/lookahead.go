@@ -0,0 +1,69 @@
+package agent
+
+import (
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Lookahead plans by simulation: each tick it tries a handful of moves on
+// clones of the game, plays each forward a few ticks, and keeps the one that
+// scores best without dying. The heuristic's choice is always a candidate
+// and wins ties, so when nothing is at stake it plays like the heuristic.
+type Lookahead struct {
+	fallback *Heuristic
+	depth    int
+}
+
+// Planning weights
+const (
+	lifePenalty     = 5000 // Points a lost life is worth to the planner
+	gameOverPenalty = 50000
+)
+
+// lookaheadMoves are tried on top of the heuristic's choice, always shooting
+var lookaheadMoves = [][]engine.Action{
+	{engine.ActionShoot},
+	{engine.ActionLeft, engine.ActionShoot},
+	{engine.ActionRight, engine.ActionShoot},
+	{engine.ActionUp, engine.ActionShoot},
+	{engine.ActionDown, engine.ActionShoot},
+}
+
+func NewLookahead(seed int64, opts Options) *Lookahead {
+	depth := opts.Depth
+	if depth < 1 {
+		depth = 1
+	}
+	return &Lookahead{fallback: NewHeuristic(seed, opts), depth: depth}
+}
+
+func (l *Lookahead) Act(g *engine.Game) []engine.Action {
+	best := l.fallback.Act(g)
+	bestValue := l.evaluate(g, best)
+	for _, moves := range lookaheadMoves {
+		if v := l.evaluate(g, moves); v > bestValue {
+			best, bestValue = moves, v
+		}
+	}
+	return best
+}
+
+// evaluate plays actions on a clone, then holds position and fires for the
+// rest of the horizon
+func (l *Lookahead) evaluate(g *engine.Game, actions []engine.Action) int {
+	sim := g.Clone()
+	for _, a := range actions {
+		sim.Apply(a)
+	}
+	sim.Step()
+	for i := 1; i < l.depth && !sim.GameOver(); i++ {
+		sim.Shoot()
+		sim.Step()
+	}
+
+	value := sim.Score() - g.Score()
+	value -= (g.Lives() - sim.Lives()) * lifePenalty
+	if sim.GameOver() {
+		value -= gameOverPenalty
+	}
+	return value
+}
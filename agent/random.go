@@ -0,0 +1,34 @@
+package agent
+
+import (
+	"math/rand"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Random ignores the board - a baseline for the other agents
+type Random struct {
+	rng *rand.Rand
+}
+
+func NewRandom(seed int64) *Random {
+	return &Random{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (r *Random) Act(g *engine.Game) []engine.Action {
+	var actions []engine.Action
+	switch r.rng.Intn(5) {
+	case 0:
+		actions = append(actions, engine.ActionLeft)
+	case 1:
+		actions = append(actions, engine.ActionRight)
+	case 2:
+		actions = append(actions, engine.ActionUp)
+	case 3:
+		actions = append(actions, engine.ActionDown)
+	}
+	if r.rng.Float64() < 0.5 {
+		actions = append(actions, engine.ActionShoot)
+	}
+	return actions
+}
@@ -1,6 +0,0 @@
-// Standalone test runner
-package main
-
-func main() {
-	runBalanceTest()
-}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// AggregateStats summarizes a simulation run
+type AggregateStats struct {
+	TotalGames           int            `json:"totalGames"`
+	AvgScore             float64        `json:"avgScore"`
+	MedianScore          float64        `json:"medianScore"`
+	AvgLivesLost         float64        `json:"avgLivesLost"`
+	AvgLevelsCompleted   float64        `json:"avgLevelsCompleted"`
+	AvgSurvivalTime      float64        `json:"avgSurvivalTime"`
+	TooEasy              int            `json:"tooEasy"`  // Games where player survived 10+ levels
+	TooHard              int            `json:"tooHard"`  // Games where player died in level 1
+	Balanced             int            `json:"balanced"` // Games with 2-9 levels completed
+	AvgDeathsByCentipede float64        `json:"avgDeathsByCentipede"`
+	CentipedeDeathRate   float64        `json:"centipedeDeathRate"`
+	AvgDeathsByEscape    float64        `json:"avgDeathsByEscape"`
+	EscapeDeathRate      float64        `json:"escapeDeathRate"`
+	AvgDeathsByFlea      float64        `json:"avgDeathsByFlea"`
+	FleaDeathRate        float64        `json:"fleaDeathRate"`
+	AvgDeathsByPoison    float64        `json:"avgDeathsByPoison"` // Poison chute deaths
+	PoisonDeathRate      float64        `json:"poisonDeathRate"`
+	AvgDeathsBySpider    float64        `json:"avgDeathsBySpider"`
+	SpiderDeathRate      float64        `json:"spiderDeathRate"`
+	AvgDeathsByScorp     float64        `json:"avgDeathsByScorp"` // Chute deaths where a scorpion laid the poison
+	ScorpionDeathRate    float64        `json:"scorpionDeathRate"`
+	AvgLoneHeads         float64        `json:"avgLoneHeads"`
+	LoneHeadGameRate     float64        `json:"loneHeadGameRate"` // Fraction of games where lone heads appeared
+	AvgHeadKills         float64        `json:"avgHeadKills"`
+	AvgBodyKills         float64        `json:"avgBodyKills"`
+	AvgEnemyKills        float64        `json:"avgEnemyKills"` // Flies, fleas, spiders and scorpions
+	Accuracy             float64        `json:"accuracy"`      // Shots hit / shots fired over every game
+	AvgMushroomsPoisoned float64        `json:"avgMushroomsPoisoned"`
+	AvgLevelTicks        float64        `json:"avgLevelTicks"` // Ticks per cleared level
+	AvgBonusLives        float64        `json:"avgBonusLives"`
+	ScoreStdDev          float64        `json:"scoreStdDev"`
+	Percentiles          map[string]int `json:"scorePercentiles"` // "p10", "p50", ...
+	Scores               []int          `json:"-"`
+}
+
+// scorePercentiles are reported in the summary and machine-readable output
+var scorePercentiles = []int{10, 25, 50, 75, 90, 95, 99}
+
+// Percentile returns the pth percentile of the sorted scores
+func (agg AggregateStats) Percentile(p int) int {
+	if len(agg.Scores) == 0 {
+		return 0
+	}
+	return agg.Scores[(p*(len(agg.Scores)-1))/100]
+}
+
+// AnalyzeBalance processes all test results
+func AnalyzeBalance(results []TestStats) AggregateStats {
+	agg := AggregateStats{
+		TotalGames: len(results),
+		Scores:     make([]int, len(results)),
+	}
+
+	totalScore := 0
+	totalLives := 0
+	totalLevels := 0
+	totalTicks := 0
+	var total struct {
+		engine.Stats
+		enemyKills  int
+		levelTicks  int
+		levelsClear int
+	}
+	gamesWithLoneHeads := 0
+	totalDeaths := 0
+
+	for i, stat := range results {
+		totalScore += stat.Score
+		totalLives += stat.LivesLost
+		totalLevels += stat.LevelsCompleted
+		totalTicks += stat.TicksAlive
+		total.DeathsByCentipede += stat.DeathsByCentipede
+		total.DeathsByEscape += stat.DeathsByEscape
+		total.DeathsByFlea += stat.DeathsByFlea
+		total.DeathsBySpider += stat.DeathsBySpider
+		total.ChuteDeaths += stat.ChuteDeaths
+		total.ScorpionChuteDeaths += stat.ScorpionChuteDeaths
+		total.LoneHeadsSpawned += stat.LoneHeadsSpawned
+		total.HeadKills += stat.HeadKills
+		total.BodyKills += stat.BodyKills
+		total.enemyKills += stat.FlyKills + stat.FleaKills + stat.SpiderKills + stat.ScorpionKills
+		total.ShotsFired += stat.ShotsFired
+		total.ShotsHit += stat.ShotsHit
+		total.MushroomsPoisoned += stat.MushroomsPoisoned
+		total.BonusLives += stat.BonusLives
+		for _, t := range stat.LevelTicks {
+			total.levelTicks += t
+			total.levelsClear++
+		}
+		if stat.LoneHeadsSpawned > 0 {
+			gamesWithLoneHeads++
+		}
+		totalDeaths += stat.LivesLost
+
+		agg.Scores[i] = stat.Score
+
+		// Categorize difficulty
+		if stat.LevelsCompleted >= 10 {
+			agg.TooEasy++
+		} else if stat.LevelsCompleted <= 1 {
+			agg.TooHard++
+		} else {
+			agg.Balanced++
+		}
+	}
+
+	agg.AvgScore = float64(totalScore) / float64(len(results))
+	agg.AvgLivesLost = float64(totalLives) / float64(len(results))
+	agg.AvgLevelsCompleted = float64(totalLevels) / float64(len(results))
+	agg.AvgSurvivalTime = float64(totalTicks) / float64(len(results))
+	perGame := func(n int) float64 { return float64(n) / float64(len(results)) }
+	agg.AvgDeathsByCentipede = perGame(total.DeathsByCentipede)
+	agg.AvgDeathsByEscape = perGame(total.DeathsByEscape)
+	agg.AvgDeathsByFlea = perGame(total.DeathsByFlea)
+	agg.AvgDeathsByPoison = perGame(total.ChuteDeaths)
+	agg.AvgDeathsBySpider = perGame(total.DeathsBySpider)
+	agg.AvgDeathsByScorp = perGame(total.ScorpionChuteDeaths)
+	agg.AvgLoneHeads = perGame(total.LoneHeadsSpawned)
+	agg.LoneHeadGameRate = perGame(gamesWithLoneHeads)
+	agg.AvgHeadKills = perGame(total.HeadKills)
+	agg.AvgBodyKills = perGame(total.BodyKills)
+	agg.AvgEnemyKills = perGame(total.enemyKills)
+	agg.Accuracy = total.Accuracy()
+	agg.AvgMushroomsPoisoned = perGame(total.MushroomsPoisoned)
+	agg.AvgBonusLives = perGame(total.BonusLives)
+	if total.levelsClear > 0 {
+		agg.AvgLevelTicks = float64(total.levelTicks) / float64(total.levelsClear)
+	}
+
+	if totalDeaths > 0 {
+		rate := func(n int) float64 { return float64(n) / float64(totalDeaths) }
+		agg.CentipedeDeathRate = rate(total.DeathsByCentipede)
+		agg.EscapeDeathRate = rate(total.DeathsByEscape)
+		agg.FleaDeathRate = rate(total.DeathsByFlea)
+		agg.PoisonDeathRate = rate(total.ChuteDeaths)
+		agg.SpiderDeathRate = rate(total.DeathsBySpider)
+		agg.ScorpionDeathRate = rate(total.ScorpionChuteDeaths)
+	}
+
+	// Calculate median score
+	sort.Ints(agg.Scores)
+	agg.MedianScore = float64(agg.Scores[len(agg.Scores)/2])
+	agg.ScoreStdDev = math.Sqrt(calculateVariance(agg.Scores))
+
+	agg.Percentiles = make(map[string]int, len(scorePercentiles))
+	for _, p := range scorePercentiles {
+		agg.Percentiles[fmt.Sprintf("p%d", p)] = agg.Percentile(p)
+	}
+
+	return agg
+}
+
+// CalculateBalanceScore rates game balance from 0-100
+func CalculateBalanceScore(agg AggregateStats) (float64, string) {
+	score := 100.0
+	feedback := []string{}
+
+	// Ideal: 60-80% of games in balanced range
+	balancedPct := float64(agg.Balanced) / float64(agg.TotalGames) * 100
+	if balancedPct < 50 {
+		penalty := (50 - balancedPct) / 2
+		score -= penalty
+		feedback = append(feedback, fmt.Sprintf("⚠️  Only %.1f%% balanced games (target: 60-80%%)", balancedPct))
+	} else if balancedPct > 90 {
+		feedback = append(feedback, fmt.Sprintf("✓ Excellent balance: %.1f%% games in 2-9 level range", balancedPct))
+	}
+
+	// Too easy check (should be < 15%)
+	easyPct := float64(agg.TooEasy) / float64(agg.TotalGames) * 100
+	if easyPct > 15 {
+		penalty := (easyPct - 15)
+		score -= penalty
+		feedback = append(feedback, fmt.Sprintf("⚠️  Too easy: %.1f%% reach 10+ levels (target: <15%%)", easyPct))
+	}
+
+	// Too hard check (should be < 20%)
+	hardPct := float64(agg.TooHard) / float64(agg.TotalGames) * 100
+	if hardPct > 20 {
+		penalty := (hardPct - 20) / 2
+		score -= penalty
+		feedback = append(feedback, fmt.Sprintf("⚠️  Too hard: %.1f%% die in level 1 (target: <20%%)", hardPct))
+	}
+
+	// Survival time (ideal: 200-400 ticks per life)
+	avgTicksPerLife := agg.AvgSurvivalTime / (agg.AvgLivesLost + 1)
+	if avgTicksPerLife < 150 {
+		penalty := (150 - avgTicksPerLife) / 10
+		score -= penalty
+		feedback = append(feedback, fmt.Sprintf("⚠️  Deaths too quick: %.0f ticks/life (target: 200-400)", avgTicksPerLife))
+	} else if avgTicksPerLife > 500 {
+		penalty := (avgTicksPerLife - 500) / 20
+		score -= penalty
+		feedback = append(feedback, fmt.Sprintf("⚠️  Lives too long: %.0f ticks/life (target: 200-400)", avgTicksPerLife))
+	}
+
+	// Poison death rate (should be 15-30% of deaths)
+	poisonPct := agg.PoisonDeathRate * 100
+	if poisonPct < 10 {
+		feedback = append(feedback, fmt.Sprintf("⚠️  Poison mushrooms underutilized: %.1f%% of deaths", poisonPct))
+		score -= 5
+	} else if poisonPct > 40 {
+		feedback = append(feedback, fmt.Sprintf("⚠️  Poison mushrooms too deadly: %.1f%% of deaths", poisonPct))
+		score -= 10
+	} else {
+		feedback = append(feedback, fmt.Sprintf("✓ Poison mushrooms well-balanced: %.1f%% of deaths", poisonPct))
+	}
+
+	// Score variance (check if games feel different)
+	variance := calculateVariance(agg.Scores)
+	stdDev := math.Sqrt(variance)
+	if stdDev < agg.AvgScore*0.3 {
+		feedback = append(feedback, "⚠️  Games too similar - needs more randomness")
+		score -= 5
+	}
+
+	feedbackStr := ""
+	for _, f := range feedback {
+		feedbackStr += f + "\n"
+	}
+
+	return score, feedbackStr
+}
+
+func calculateVariance(scores []int) float64 {
+	sum := 0
+	for _, s := range scores {
+		sum += s
+	}
+	mean := float64(sum) / float64(len(scores))
+
+	variance := 0.0
+	for _, s := range scores {
+		diff := float64(s) - mean
+		variance += diff * diff
+	}
+	return variance / float64(len(scores))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Variant is one named configuration in an A/B experiment
+type Variant struct {
+	Name      string
+	Overrides []string // key=value pairs on top of the shared config
+	Options   SimOptions
+}
+
+// parseVariant reads "name:key=value,key=value". The overrides apply on top
+// of base, so "baseline:" (or just "baseline") runs the shared config as is.
+func parseVariant(spec string, base SimOptions) (Variant, error) {
+	name, pairs, _ := strings.Cut(spec, ":")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Variant{}, fmt.Errorf("variant %q has no name", spec)
+	}
+	v := Variant{Name: name, Options: base}
+	for _, pair := range strings.Split(pairs, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		if err := v.Options.Config.SetPair(pair); err != nil {
+			return Variant{}, fmt.Errorf("variant %s: %w", name, err)
+		}
+		v.Overrides = append(v.Overrides, pair)
+	}
+	return v, nil
+}
+
+// metric is a per-game number the experiment tests
+type metric struct {
+	Name  string
+	Value func(TestStats) float64
+}
+
+var metrics = []metric{
+	{"Score", func(s TestStats) float64 { return float64(s.Score) }},
+	{"Lives Lost", func(s TestStats) float64 { return float64(s.LivesLost) }},
+	{"Levels Completed", func(s TestStats) float64 { return float64(s.LevelsCompleted) }},
+	{"Survival Time", func(s TestStats) float64 { return float64(s.TicksAlive) }},
+	// Same definition as the single run report's ticks/life
+	{"Ticks/Life", func(s TestStats) float64 { return float64(s.TicksAlive) / float64(s.LivesLost+1) }},
+}
+
+// MetricAnalysis holds every test for one metric across all variants
+type MetricAnalysis struct {
+	Metric    metric
+	Groups    [][]float64 // One sample per variant, in variant order
+	Summaries []Summary
+	ANOVA     ANOVA
+	Levene    ANOVA
+	Tukey     []Comparison
+}
+
+// Experiment is a finished A/B (or A/B/n) comparison. Every variant played
+// the same seeds, so differences come from the config, not from luck.
+type Experiment struct {
+	Date     time.Time
+	BaseSeed int64
+	Games    int
+	Variants []Variant
+	Runs     []RunReport // One per variant
+	Metrics  []MetricAnalysis
+}
+
+// analyzeExperiment runs the statistics over finished variant runs
+func analyzeExperiment(variants []Variant, runs []RunReport, baseSeed int64, games int) Experiment {
+	exp := Experiment{
+		Date:     time.Now(),
+		BaseSeed: baseSeed,
+		Games:    games,
+		Variants: variants,
+		Runs:     runs,
+	}
+	for _, m := range metrics {
+		ma := MetricAnalysis{Metric: m}
+		for _, run := range runs {
+			sample := make([]float64, len(run.Results))
+			for i, s := range run.Results {
+				sample[i] = m.Value(s)
+			}
+			ma.Groups = append(ma.Groups, sample)
+			ma.Summaries = append(ma.Summaries, Summarize(sample))
+		}
+		ma.ANOVA = OneWayANOVA(ma.Groups)
+		ma.Levene = Levene(ma.Groups)
+		ma.Tukey = TukeyHSD(ma.Groups, ma.ANOVA)
+		exp.Metrics = append(exp.Metrics, ma)
+	}
+	return exp
+}
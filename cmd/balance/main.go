@@ -0,0 +1,310 @@
+// Headless balance simulator for Centipede
+// Plays many automated games in parallel and reports difficulty metrics
+// Run with: go run ./cmd/balance -games 1000 -json results.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michaellavery-grp/centipede/agent"
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// stringList collects repeated string flags
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, " ") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func main() {
+	defaults := DefaultSimOptions()
+	opts := defaults
+
+	games := flag.Int("games", 1000, "number of games to simulate (per variant)")
+	workers := flag.Int("workers", runtime.NumCPU(), "games to run concurrently")
+	baseSeed := flag.Int64("seed", 0, "base seed; game i uses seed+i (default: time based)")
+	flag.IntVar(&opts.Width, "width", defaults.Width, "board width")
+	flag.IntVar(&opts.Height, "height", defaults.Height, "board height")
+	flag.StringVar(&opts.Strategy, "strategy", defaults.Strategy,
+		"AI agent: "+strings.Join(agent.Names(), ", "))
+	flag.IntVar(&opts.MaxTicks, "max-ticks", defaults.MaxTicks, "tick limit per game")
+	flag.IntVar(&opts.Agent.DodgeRange, "dodge", defaults.Agent.DodgeRange, "heuristic: rows from the bottom that trigger dodging")
+	flag.Float64Var(&opts.Agent.ShootChance, "shoot", defaults.Agent.ShootChance, "heuristic: chance to shoot at a lined up target")
+	flag.IntVar(&opts.Agent.Depth, "depth", defaults.Agent.Depth, "lookahead: ticks simulated per candidate move")
+	flag.IntVar(&opts.Agent.ReactionTicks, "reaction", defaults.Agent.ReactionTicks, "human: reaction delay in ticks")
+	flag.Float64Var(&opts.Agent.ErrorRate, "errors", defaults.Agent.ErrorRate, "human: chance each decision comes out wrong")
+	var sets, variantSpecs stringList
+	flag.Var(&sets, "set", "engine config override key=value, repeatable (keys: "+
+		strings.Join(engine.ConfigKeys(), ", ")+")")
+	flag.Var(&variantSpecs, "variant", "named config variant name:key=value,key=value; give two or more to compare them")
+	jsonPath := flag.String("json", "", "write the full run (config, aggregate, every game) as JSON")
+	csvPath := flag.String("csv", "", "write one CSV row per game")
+	summaryPath := flag.String("summary-csv", "", "write the aggregate as a CSV row per variant")
+	reportPath := flag.String("report", "", "with variants, write the Markdown analysis here instead of stdout")
+	quiet := flag.Bool("quiet", false, "skip the human-readable report")
+	flag.Parse()
+
+	for _, pair := range sets {
+		if err := opts.Config.SetPair(pair); err != nil {
+			fail(err)
+		}
+	}
+	if _, err := agent.New(opts.Strategy, 0, opts.Agent); err != nil {
+		fail(err)
+	}
+	if err := engine.CheckSize(opts.Width, opts.Height); err != nil {
+		fail(err)
+	}
+	if *games < 1 || *workers < 1 {
+		fail(fmt.Errorf("-games and -workers must be at least 1"))
+	}
+	if len(variantSpecs) == 1 {
+		fail(fmt.Errorf("-variant needs a second variant to compare against"))
+	}
+	variants := []Variant{{Name: "default", Options: opts}}
+	if len(variantSpecs) > 0 {
+		variants = variants[:0]
+		for _, spec := range variantSpecs {
+			v, err := parseVariant(spec, opts)
+			if err != nil {
+				fail(err)
+			}
+			variants = append(variants, v)
+		}
+	}
+	comparing := len(variants) > 1
+
+	// Game i uses baseSeed+i, so a run can be reproduced from its base seed.
+	// Variants share the seeds, so they play matched games.
+	if *baseSeed == 0 {
+		*baseSeed = time.Now().UnixNano()
+	}
+
+	if !*quiet && !comparing {
+		fmt.Println("🐛 CENTIPEDE BALANCE TEST HARNESS")
+		fmt.Println("==================================")
+		fmt.Printf("Simulating %d games with %s AI on %d workers...\n",
+			*games, opts.Strategy, *workers)
+		fmt.Printf("Base seed: %d\n", *baseSeed)
+		fmt.Println()
+	}
+
+	runs := make([]RunReport, len(variants))
+	for i, v := range variants {
+		if comparing {
+			fmt.Fprintf(os.Stderr, "Variant %s\n", v.Name)
+		}
+		start := time.Now()
+		results := runGames(*games, *workers, *baseSeed, v.Options)
+		fmt.Fprintf(os.Stderr, "%d games in %s\n", *games, time.Since(start).Round(time.Millisecond))
+		runs[i] = newRunReport(v, *baseSeed, results)
+	}
+
+	if comparing {
+		writeOutput(*jsonPath, func(p string) error { return writeJSON(p, runs) })
+	} else {
+		writeOutput(*jsonPath, func(p string) error { return writeJSON(p, runs[0]) })
+	}
+	writeOutput(*csvPath, func(p string) error { return writeResultsCSV(p, runs) })
+	writeOutput(*summaryPath, func(p string) error { return writeSummaryCSV(p, runs) })
+
+	if comparing {
+		exp := analyzeExperiment(variants, runs, *baseSeed, *games)
+		if *reportPath != "" || !*quiet {
+			if err := writeMarkdown(*reportPath, exp); err != nil {
+				fail(err)
+			}
+		}
+	} else if !*quiet {
+		_, feedback := CalculateBalanceScore(runs[0].Aggregate)
+		printReport(opts, runs[0].Aggregate, runs[0].BalanceScore, feedback)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "balance:", err)
+	os.Exit(2)
+}
+
+// runGames spreads the games over a pool of workers. Every game owns its
+// RNGs, so results[i] depends only on baseSeed+i, never on scheduling.
+func runGames(games, workers int, baseSeed int64, opts SimOptions) []TestStats {
+	results := make([]TestStats, games)
+	jobs := make(chan int)
+	var done sync.WaitGroup
+	var mu sync.Mutex
+	completed := 0
+
+	for w := 0; w < workers; w++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			for i := range jobs {
+				results[i] = SimulateGame(baseSeed+int64(i), opts)
+
+				mu.Lock()
+				completed++
+				if completed%100 == 0 || completed == games {
+					fmt.Fprintf(os.Stderr, "Progress: %d/%d games completed\n", completed, games)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < games; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	done.Wait()
+	return results
+}
+
+// writeOutput runs write when path was given, exiting on failure
+func writeOutput(path string, write func(string) error) {
+	if path == "" {
+		return
+	}
+	if err := write(path); err != nil {
+		fmt.Fprintln(os.Stderr, "balance:", err)
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stderr, "Wrote", path)
+}
+
+// printReport prints the human-readable analysis
+func printReport(opts SimOptions, agg AggregateStats, balanceScore float64, feedback string) {
+	// Print detailed report
+	fmt.Println("📊 AGGREGATE STATISTICS")
+	fmt.Println("========================")
+	fmt.Printf("Total Games Simulated:  %d\n", agg.TotalGames)
+	fmt.Printf("Average Score:          %.0f\n", agg.AvgScore)
+	fmt.Printf("Median Score:           %.0f\n", agg.MedianScore)
+	fmt.Printf("Average Lives Lost:     %.2f / %d\n", agg.AvgLivesLost, opts.Config.StartingLives)
+	fmt.Printf("Average Levels Done:    %.2f\n", agg.AvgLevelsCompleted)
+	fmt.Printf("Avg Survival Time:      %.0f ticks (~%.1f seconds)\n",
+		agg.AvgSurvivalTime, agg.AvgSurvivalTime*engine.TickDuration.Seconds())
+	fmt.Println()
+
+	fmt.Println("🎯 DIFFICULTY DISTRIBUTION")
+	fmt.Println("===========================")
+	fmt.Printf("Too Easy (10+ levels):  %d games (%.1f%%)\n",
+		agg.TooEasy, float64(agg.TooEasy)/float64(agg.TotalGames)*100)
+	fmt.Printf("Balanced (2-9 levels):  %d games (%.1f%%)\n",
+		agg.Balanced, float64(agg.Balanced)/float64(agg.TotalGames)*100)
+	fmt.Printf("Too Hard (0-1 levels):  %d games (%.1f%%)\n",
+		agg.TooHard, float64(agg.TooHard)/float64(agg.TotalGames)*100)
+	fmt.Println()
+
+	fmt.Println("☠️  DEATH ANALYSIS")
+	fmt.Println("===================")
+	fmt.Printf("Avg Deaths by Centipede: %.2f (%.1f%% of all deaths)\n", agg.AvgDeathsByCentipede, agg.CentipedeDeathRate*100)
+	fmt.Printf("Avg Deaths by Escape:    %.2f (%.1f%%)\n", agg.AvgDeathsByEscape, agg.EscapeDeathRate*100)
+	fmt.Printf("Avg Deaths by Flea:      %.2f (%.1f%%)\n", agg.AvgDeathsByFlea, agg.FleaDeathRate*100)
+	fmt.Printf("Avg Deaths by Spider:    %.2f (%.1f%%)\n", agg.AvgDeathsBySpider, agg.SpiderDeathRate*100)
+	fmt.Printf("Avg Deaths by Poison:    %.2f (%.1f%%, centipedes fresh off a poison chute)\n", agg.AvgDeathsByPoison, agg.PoisonDeathRate*100)
+	fmt.Printf("Avg Deaths by Scorpion:  %.2f (%.1f%%, chutes whose poison a scorpion laid)\n", agg.AvgDeathsByScorp, agg.ScorpionDeathRate*100)
+	fmt.Println()
+
+	fmt.Println("🔫 COMBAT")
+	fmt.Println("=========")
+	fmt.Printf("Avg Heads Shot:         %.2f\n", agg.AvgHeadKills)
+	fmt.Printf("Avg Body Segments Shot: %.2f\n", agg.AvgBodyKills)
+	fmt.Printf("Avg Other Enemies Shot: %.2f (flies, fleas, spiders, scorpions)\n", agg.AvgEnemyKills)
+	fmt.Printf("Accuracy:               %.1f%%\n", agg.Accuracy*100)
+	fmt.Printf("Avg Mushrooms Poisoned: %.2f\n", agg.AvgMushroomsPoisoned)
+	fmt.Printf("Avg Ticks per Level:    %.0f\n", agg.AvgLevelTicks)
+	fmt.Printf("Avg Bonus Lives:        %.2f\n", agg.AvgBonusLives)
+	fmt.Println()
+
+	fmt.Println("🐛 PLAYER ZONE PRESSURE")
+	fmt.Println("========================")
+	fmt.Printf("Avg Lone Heads Spawned: %.2f per game\n", agg.AvgLoneHeads)
+	fmt.Printf("Games With Lone Heads:  %.1f%%\n", agg.LoneHeadGameRate*100)
+	fmt.Println()
+
+	fmt.Println("📈 SCORE DISTRIBUTION")
+	fmt.Println("=====================")
+	for _, p := range scorePercentiles {
+		fmt.Printf("%2dth percentile:        %d\n", p, agg.Percentile(p))
+	}
+	fmt.Println()
+
+	fmt.Println("⚖️  BALANCE SCORE")
+	fmt.Println("=================")
+	fmt.Printf("Overall Rating: %.1f / 100\n\n", balanceScore)
+	fmt.Println(feedback)
+
+	// Recommendations
+	fmt.Println("💡 RECOMMENDATIONS")
+	fmt.Println("===================")
+
+	balancedPct := float64(agg.Balanced) / float64(agg.TotalGames) * 100
+	if balancedPct < 60 {
+		fmt.Println("❌ Game needs difficulty tuning")
+
+		hardPct := float64(agg.TooHard) / float64(agg.TotalGames) * 100
+		if hardPct > 25 {
+			fmt.Println("   → Reduce centipede speed")
+			fmt.Println("   → Increase initial lives to 4")
+			fmt.Println("   → Reduce poison mushroom spawn rate")
+		}
+
+		easyPct := float64(agg.TooEasy) / float64(agg.TotalGames) * 100
+		if easyPct > 15 {
+			fmt.Println("   → Increase centipede spawn rate")
+			fmt.Println("   → Add more mushrooms per level")
+			fmt.Println("   → Increase fly spawn rate")
+		}
+	} else if balancedPct >= 60 && balancedPct <= 80 {
+		fmt.Println("✅ Game balance is GOOD - within target range!")
+		fmt.Println("   → Minor tweaks may still improve player experience")
+	} else {
+		fmt.Println("✅ Game balance is EXCELLENT!")
+		fmt.Println("   → Current difficulty curve is well-tuned")
+	}
+
+	// Frustration vs Ease analysis
+	fmt.Println()
+	fmt.Println("😤 FRUSTRATION VS EASE ANALYSIS")
+	fmt.Println("=================================")
+
+	avgTicksPerLife := agg.AvgSurvivalTime / (agg.AvgLivesLost + 1)
+
+	if avgTicksPerLife < 150 {
+		fmt.Println("⚠️  HIGH FRUSTRATION - Deaths feel unfair/too quick")
+		fmt.Println("   Players don't have time to react to threats")
+	} else if avgTicksPerLife > 500 {
+		fmt.Println("⚠️  TOO EASY - Game feels childish/unchallenging")
+		fmt.Println("   Players can survive too long without skill")
+	} else if avgTicksPerLife >= 200 && avgTicksPerLife <= 350 {
+		fmt.Println("✅ OPTIMAL - Good balance of challenge and fairness")
+		fmt.Println("   Players have time to react but must stay alert")
+	} else {
+		fmt.Println("✓ ACCEPTABLE - Balance is decent but could be better")
+	}
+
+	fmt.Println()
+	fmt.Printf("Target: 200-350 ticks/life | Actual: %.0f ticks/life\n", avgTicksPerLife)
+
+	// Final verdict
+	fmt.Println()
+	fmt.Println("==================================================")
+	if balanceScore >= 80 {
+		fmt.Println("🏆 VERDICT: Game is well-balanced and ready!")
+	} else if balanceScore >= 60 {
+		fmt.Println("👍 VERDICT: Game is playable but needs minor tuning")
+	} else {
+		fmt.Println("⚠️  VERDICT: Game needs significant rebalancing")
+	}
+	fmt.Println("==")
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/michaellavery-grp/centipede/agent"
+)
+
+// RunReport is everything a run produced, in the shape written by -json
+type RunReport struct {
+	Variant      string         `json:"variant"`
+	BaseSeed     int64          `json:"baseSeed"`
+	Games        int            `json:"games"`
+	Width        int            `json:"width"`
+	Height       int            `json:"height"`
+	Strategy     string         `json:"strategy"`
+	MaxTicks     int            `json:"maxTicks"`
+	Agent        agent.Options  `json:"agent"`
+	Config       []string       `json:"config"` // key=value, as accepted by -set
+	Aggregate    AggregateStats `json:"aggregate"`
+	BalanceScore float64        `json:"balanceScore"`
+	Results      []TestStats    `json:"results"`
+}
+
+// csvHeader matches the column order written by csvRow
+var csvHeader = []string{
+	"variant", "seed", "score", "livesLost", "levelsCompleted", "finalLevel",
+	"ticksAlive", "headKills", "bodyKills", "flyKills", "fleaKills",
+	"spiderKills", "scorpionKills", "shotsFired", "shotsHit",
+	"mushroomsDestroyed", "mushroomsCreated", "mushroomsPoisoned", "mushroomsEaten",
+	"deathsByCentipede", "deathsByEscape", "deathsByFlea", "deathsBySpider",
+	"chuteDeaths", "scorpionChuteDeaths", "loneHeadsSpawned", "bonusLives",
+	"levelTicks",
+}
+
+func csvRow(variant string, s TestStats) []string {
+	ints := []int{
+		s.Score, s.LivesLost, s.LevelsCompleted, s.FinalLevel,
+		s.TicksAlive, s.HeadKills, s.BodyKills, s.FlyKills, s.FleaKills,
+		s.SpiderKills, s.ScorpionKills, s.ShotsFired, s.ShotsHit,
+		s.MushroomsDestroyed, s.MushroomsCreated, s.MushroomsPoisoned, s.MushroomsEaten,
+		s.DeathsByCentipede, s.DeathsByEscape, s.DeathsByFlea, s.DeathsBySpider,
+		s.ChuteDeaths, s.ScorpionChuteDeaths, s.LoneHeadsSpawned, s.BonusLives,
+	}
+	row := []string{variant, strconv.FormatInt(s.Seed, 10)}
+	for _, v := range ints {
+		row = append(row, strconv.Itoa(v))
+	}
+	// Ticks per cleared level, space separated so the column stays a cell
+	levels := make([]string, len(s.LevelTicks))
+	for i, t := range s.LevelTicks {
+		levels[i] = strconv.Itoa(t)
+	}
+	return append(row, strings.Join(levels, " "))
+}
+
+// newRunReport bundles a variant's results with its settings and analysis
+func newRunReport(v Variant, baseSeed int64, results []TestStats) RunReport {
+	agg := AnalyzeBalance(results)
+	balanceScore, _ := CalculateBalanceScore(agg)
+	return RunReport{
+		Variant:      v.Name,
+		BaseSeed:     baseSeed,
+		Games:        len(results),
+		Width:        v.Options.Width,
+		Height:       v.Options.Height,
+		Strategy:     v.Options.Strategy,
+		MaxTicks:     v.Options.MaxTicks,
+		Agent:        v.Options.Agent,
+		Config:       v.Options.Config.Pairs(),
+		Aggregate:    agg,
+		BalanceScore: balanceScore,
+		Results:      results,
+	}
+}
+
+// writeJSON writes the full report, including every game
+func writeJSON(path string, report any) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// writeResultsCSV writes one row per game
+func writeResultsCSV(path string, runs []RunReport) error {
+	rows := [][]string{csvHeader}
+	for _, run := range runs {
+		for _, s := range run.Results {
+			rows = append(rows, csvRow(run.Variant, s))
+		}
+	}
+	return writeCSV(path, rows)
+}
+
+// writeSummaryCSV writes an aggregate row per variant, handy for collecting
+// runs from a parameter sweep into one spreadsheet
+func writeSummaryCSV(path string, runs []RunReport) error {
+	header := []string{
+		"variant", "baseSeed", "games", "width", "height", "strategy", "config",
+		"avgScore", "medianScore", "scoreStdDev", "avgLivesLost",
+		"avgLevelsCompleted", "avgSurvivalTime", "tooEasy", "balanced",
+		"tooHard", "centipedeDeathRate", "escapeDeathRate", "fleaDeathRate",
+		"poisonDeathRate", "spiderDeathRate", "scorpionDeathRate",
+		"avgLoneHeads", "accuracy", "avgLevelTicks", "balanceScore",
+	}
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 4, 64) }
+	rows := [][]string{header}
+	for _, report := range runs {
+		agg := report.Aggregate
+		rows = append(rows, []string{
+			report.Variant,
+			strconv.FormatInt(report.BaseSeed, 10),
+			strconv.Itoa(report.Games),
+			strconv.Itoa(report.Width),
+			strconv.Itoa(report.Height),
+			report.Strategy,
+			strings.Join(report.Config, " "),
+			f(agg.AvgScore), f(agg.MedianScore), f(agg.ScoreStdDev), f(agg.AvgLivesLost),
+			f(agg.AvgLevelsCompleted), f(agg.AvgSurvivalTime),
+			strconv.Itoa(agg.TooEasy), strconv.Itoa(agg.Balanced), strconv.Itoa(agg.TooHard),
+			f(agg.CentipedeDeathRate), f(agg.EscapeDeathRate), f(agg.FleaDeathRate),
+			f(agg.PoisonDeathRate), f(agg.SpiderDeathRate), f(agg.ScorpionDeathRate),
+			f(agg.AvgLoneHeads), f(agg.Accuracy), f(agg.AvgLevelTicks), f(report.BalanceScore),
+		})
+	}
+	return writeCSV(path, rows)
+}
+
+func writeCSV(path string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
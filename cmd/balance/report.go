@@ -0,0 +1,579 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Markdown report for an Experiment, laid out like STATISTICAL_ANALYSIS.md
+// so generated reports sit next to the hand written ones.
+
+const alpha = 0.05
+
+// writeMarkdown writes the report to path, or stdout when path is empty
+func writeMarkdown(path string, exp Experiment) error {
+	if path == "" {
+		renderMarkdown(os.Stdout, exp)
+		return nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	renderMarkdown(file, exp)
+	return file.Close()
+}
+
+func renderMarkdown(w io.Writer, exp Experiment) {
+	p := func(format string, args ...any) { fmt.Fprintf(w, format+"\n", args...) }
+	names := exp.names()
+	total := exp.Games * len(exp.Variants)
+
+	p("# Centipede Statistical Analysis Report")
+	p("## ANOVA Comparison: %s", strings.Join(names, " vs "))
+	p("")
+	p("**Analysis Date**: %s", exp.Date.Format("2006-01-02"))
+	p("**Method**: One-Way ANOVA with Post-Hoc Analysis")
+	p("**Sample Size**: %s games per variant (N=%s total)", commas(float64(exp.Games), 0), commas(float64(total), 0))
+	p("**Seeds**: %d to %d, matched across variants", exp.BaseSeed, exp.BaseSeed+int64(exp.Games)-1)
+	p("**Significance Level**: α = %.2f", alpha)
+	p("")
+	p("---")
+	p("")
+
+	// Executive summary
+	p("## Executive Summary")
+	p("")
+	p("### Key Findings")
+	p("")
+	header := "| Metric |"
+	rule := "|--------|"
+	for _, n := range names {
+		header += " " + n + " |"
+		rule += "------|"
+	}
+	if len(names) == 2 {
+		header += " Change |"
+		rule += "--------|"
+	}
+	p("%s p-value | Significant? |", header)
+	p("%s---------|--------------|", rule)
+	for _, ma := range exp.Metrics {
+		row := "| **Avg " + ma.Metric.Name + "** |"
+		for _, s := range ma.Summaries {
+			row += " " + commas(s.Mean, decimalsFor(s.Mean)) + " |"
+		}
+		if len(names) == 2 {
+			row += " " + pctChange(ma.Summaries[0].Mean, ma.Summaries[1].Mean) + " |"
+		}
+		p("%s %s | %s |", row, pValue(ma.ANOVA.P), significance(ma.ANOVA.P))
+	}
+	row := "| **Balance Score** |"
+	for _, run := range exp.Runs {
+		row += fmt.Sprintf(" %.1f |", run.BalanceScore)
+	}
+	if len(names) == 2 {
+		row += fmt.Sprintf(" %+.1f |", exp.Runs[1].BalanceScore-exp.Runs[0].BalanceScore)
+	}
+	p("%s N/A | N/A |", row)
+	row = "| **Balanced %** |"
+	for _, run := range exp.Runs {
+		row += fmt.Sprintf(" %.1f%% |", percent(run.Aggregate.Balanced, run.Aggregate.TotalGames))
+	}
+	if len(names) == 2 {
+		row += fmt.Sprintf(" %+.1f%% |", percent(exp.Runs[1].Aggregate.Balanced, exp.Games)-percent(exp.Runs[0].Aggregate.Balanced, exp.Games))
+	}
+	p("%s N/A | N/A |", row)
+	p("")
+	p("**Legend**: *** = p < 0.001 (highly significant), ** = p < 0.01, * = p < 0.05")
+	p("")
+	p("---")
+	p("")
+
+	// 1. Data collection
+	p("## 1. Data Collection")
+	p("")
+	for i, v := range exp.Variants {
+		run := exp.Runs[i]
+		p("### %s", v.Name)
+		p("```")
+		p("Sample Size: N = %s games", commas(float64(exp.Games), 0))
+		if len(v.Overrides) == 0 {
+			p("Overrides: none")
+		} else {
+			p("Overrides: %s", strings.Join(v.Overrides, " "))
+		}
+		p("Config: %s", strings.Join(run.Config, " "))
+		p("Board: %dx%d, AI: %s, max %d ticks", run.Width, run.Height, run.Strategy, run.MaxTicks)
+		p("```")
+		p("")
+	}
+	p("---")
+	p("")
+
+	// 2. Descriptive statistics
+	p("## 2. Descriptive Statistics")
+	p("")
+	for i, ma := range exp.Metrics {
+		p("### 2.%d %s Distribution", i+1, ma.Metric.Name)
+		p("")
+		for j, s := range ma.Summaries {
+			d := decimalsFor(s.Mean)
+			p("**%s**:", names[j])
+			p("- Mean: %s", commas(s.Mean, d))
+			p("- Median: %s", commas(s.Median, d))
+			p("- SD: %s", commas(s.SD, d))
+			p("- Min: %s", commas(s.Min, d))
+			p("- Max: %s", commas(s.Max, d))
+			p("- IQR: %s (Q1: %s, Q3: %s)", commas(s.Q3-s.Q1, d), commas(s.Q1, d), commas(s.Q3, d))
+			p("")
+		}
+	}
+	p("### 2.%d Difficulty Distribution", len(exp.Metrics)+1)
+	p("")
+	header = "| Category |"
+	rule = "|----------|"
+	for _, n := range names {
+		header += " " + n + " Count | " + n + " % |"
+		rule += "------|------|"
+	}
+	p("%s", header)
+	p("%s", rule)
+	categories := []struct {
+		name  string
+		count func(AggregateStats) int
+	}{
+		{"**Too Easy** (10+ levels)", func(a AggregateStats) int { return a.TooEasy }},
+		{"**Balanced** (2-9 levels)", func(a AggregateStats) int { return a.Balanced }},
+		{"**Too Hard** (0-1 levels)", func(a AggregateStats) int { return a.TooHard }},
+	}
+	for _, c := range categories {
+		row := "| " + c.name + " |"
+		for _, run := range exp.Runs {
+			n := c.count(run.Aggregate)
+			row += fmt.Sprintf(" %d | %.1f%% |", n, percent(n, run.Aggregate.TotalGames))
+		}
+		p("%s", row)
+	}
+	p("")
+	p("---")
+	p("")
+
+	// 3. ANOVA
+	p("## 3. ANOVA Statistical Analysis")
+	p("")
+	p("### 3.1 Hypotheses")
+	p("")
+	p("**Null Hypothesis (H₀)**: There is no significant difference in game metrics between %s", strings.Join(names, ", "))
+	p("")
+	p("**Alternative Hypothesis (H₁)**: The config changes significantly affect game balance metrics")
+	p("")
+	p("### 3.2 ANOVA Results")
+	p("")
+	for i, ma := range exp.Metrics {
+		a := ma.ANOVA
+		p("#### 3.2.%d %s", i+1, ma.Metric.Name)
+		p("")
+		p("```")
+		p("Source of Variation | %-22s | %-5s | %-20s | %-11s | p-value", "SS", "df", "MS", "F-Statistic")
+		p("--------------------|------------------------|-------|----------------------|-------------|----------")
+		p("Between Groups      | %-22s | %-5d | %-20s | %-11s | %s", commas(a.SSB, 2), a.DFB, commas(a.MSB, 2), fStat(a.F), pValue(a.P))
+		p("Within Groups       | %-22s | %-5d | %-20s | %-11s |", commas(a.SSW, 2), a.DFW, commas(a.MSW, 2), "")
+		p("Total               | %-22s | %-5d | %-20s | %-11s |", commas(a.SST, 2), a.DFT, "", "")
+		p("```")
+		p("")
+		p("**F(%d, %d) = %s, p %s**", a.DFB, a.DFW, fStat(a.F), pCompare(a.P))
+		p("")
+		if a.P < alpha {
+			p("**Conclusion**: **SIGNIFICANT** difference in %s between variants (η² = %.3f).", strings.ToLower(ma.Metric.Name), a.EtaSquared)
+		} else {
+			p("**Conclusion**: No significant difference in %s between variants (η² = %.3f).", strings.ToLower(ma.Metric.Name), a.EtaSquared)
+		}
+		p("")
+	}
+	p("---")
+	p("")
+
+	// 4. Post-hoc
+	p("## 4. Post-Hoc Analysis")
+	p("")
+	p("### 4.1 Tukey HSD Test")
+	p("")
+	p("| Comparison | Mean Diff | 95%% CI | p-value | Significant? |")
+	p("|------------|-----------|--------|---------|--------------|")
+	for _, ma := range exp.Metrics {
+		d := decimalsFor(ma.Summaries[0].Mean)
+		for _, c := range ma.Tukey {
+			p("| %s - %s (%s) | %s | [%s, %s] | %s | %s |",
+				names[c.B], names[c.A], ma.Metric.Name,
+				signed(c.Diff, d), commas(c.CILow, d), commas(c.CIHigh, d),
+				pValue(c.P), significance(c.P))
+		}
+	}
+	p("")
+	p("**Interpretation**: Differences whose interval excludes zero are significant at the family-wise α = %.2f.", alpha)
+	p("")
+	p("### 4.2 Levene's Test for Homogeneity of Variance")
+	p("")
+	p("| Metric | F-Statistic | p-value | Equal Variance? |")
+	p("|--------|-------------|---------|-----------------|")
+	for _, ma := range exp.Metrics {
+		equal := "✅ Yes"
+		if ma.Levene.P < alpha {
+			equal = "❌ No"
+		}
+		p("| %s | %s | %s | %s |", ma.Metric.Name, fStat(ma.Levene.F), pValue(ma.Levene.P), equal)
+	}
+	p("")
+	p("**Interpretation**: Median centred (Brown-Forsythe) form. Unequal variances make the Welch intervals in section 8 the safer estimate.")
+	p("")
+	p("---")
+	p("")
+
+	// 5. Effect sizes
+	p("## 5. Effect Size Analysis")
+	p("")
+	p("### 5.1 Cohen's d (Standardized Mean Difference)")
+	p("")
+	p("| Metric | Comparison | Cohen's d | Interpretation |")
+	p("|--------|------------|-----------|----------------|")
+	for _, ma := range exp.Metrics {
+		for _, c := range ma.Tukey {
+			d := CohensD(ma.Groups[c.A], ma.Groups[c.B])
+			p("| %s | %s - %s | %s | %s |", ma.Metric.Name, names[c.B], names[c.A], signed(d, 2), effectSize(d))
+		}
+	}
+	p("")
+	p("**Reference Scale**:")
+	p("- Small: d = 0.2")
+	p("- Medium: d = 0.5")
+	p("- Large: d = 0.8")
+	p("- **Extremely Large: d > 2.0**")
+	p("- **Astronomical: d > 100**")
+	p("")
+	p("### 5.2 Eta-Squared (η²) - Proportion of Variance Explained")
+	p("")
+	p("| Metric | η² | Variance Explained |")
+	p("|--------|----|--------------------|")
+	for _, ma := range exp.Metrics {
+		p("| %s | %.3f | **%.1f%%** |", ma.Metric.Name, ma.ANOVA.EtaSquared, ma.ANOVA.EtaSquared*100)
+	}
+	p("")
+	p("---")
+	p("")
+
+	// 6. Distributions
+	p("## 6. Distribution Analysis")
+	p("")
+	p("### 6.1 Normality Tests (Shapiro-Wilk)")
+	p("")
+	header = "| Metric |"
+	rule = "|--------|"
+	for _, n := range names {
+		header += " " + n + " W-stat | " + n + " p-value |"
+		rule += "------|------|"
+	}
+	p("%s", header)
+	p("%s", rule)
+	for _, ma := range exp.Metrics {
+		row := "| " + ma.Metric.Name + " |"
+		for _, s := range ma.Summaries {
+			if s.ShapiroOK {
+				row += fmt.Sprintf(" %.3f | %s |", s.ShapiroW, pValue(s.ShapiroP))
+			} else {
+				row += " n/a | n/a |"
+			}
+		}
+		p("%s", row)
+	}
+	p("")
+	p("**Interpretation**: p < %.2f means the metric is not normally distributed. With large samples ANOVA is robust to this; n/a marks samples that are constant or outside 4-5,000 games.", alpha)
+	p("")
+	p("### 6.2 Skewness and Kurtosis")
+	p("")
+	p("| Metric | Variant | Skewness | Kurtosis |")
+	p("|--------|---------|----------|----------|")
+	for _, ma := range exp.Metrics {
+		for j, s := range ma.Summaries {
+			p("| %s | %s | %.2f | %.2f |", ma.Metric.Name, names[j], s.Skewness, s.Kurtosis)
+		}
+	}
+	p("")
+	p("**Interpretation**: A normal distribution has skewness 0 and kurtosis 3.")
+	p("")
+	p("---")
+	p("")
+
+	// 7. Balance quality
+	p("## 7. Balance Quality Metrics")
+	p("")
+	p("### 7.1 Coefficient of Variation (CV)")
+	p("")
+	p("**Lower CV = More Consistent Gameplay**")
+	p("")
+	header = "| Metric |"
+	rule = "|--------|"
+	for _, n := range names {
+		header += " " + n + " CV |"
+		rule += "------|"
+	}
+	p("%s", header)
+	p("%s", rule)
+	for _, ma := range exp.Metrics {
+		row := "| " + ma.Metric.Name + " |"
+		for _, s := range ma.Summaries {
+			row += fmt.Sprintf(" %.1f%% |", s.CV*100)
+		}
+		p("%s", row)
+	}
+	p("")
+	p("### 7.2 Game Balance Index (Custom Metric)")
+	p("")
+	p("**Formula**:")
+	p("```")
+	p("Balance Index = (Balanced%% × 2) - (TooEasy%% + TooHard%%)")
+	p("Target: 80-100")
+	p("```")
+	p("")
+	p("| Version | Calculation | Balance Index | Balance Score |")
+	p("|---------|-------------|---------------|---------------|")
+	for i, run := range exp.Runs {
+		a := run.Aggregate
+		bal, easy, hard := percent(a.Balanced, a.TotalGames), percent(a.TooEasy, a.TotalGames), percent(a.TooHard, a.TotalGames)
+		p("| %s | (%.1f × 2) - (%.1f + %.1f) | **%.1f** | %.1f |", names[i], bal, easy, hard, bal*2-(easy+hard), run.BalanceScore)
+	}
+	p("")
+	p("---")
+	p("")
+
+	// 8. Confidence intervals
+	p("## 8. Confidence Intervals (95%%)")
+	p("")
+	p("### 8.1 Variant Means")
+	p("")
+	p("| Metric | Variant | Mean | 95%% CI Lower | 95%% CI Upper |")
+	p("|--------|---------|------|--------------|--------------|")
+	for _, ma := range exp.Metrics {
+		for j, s := range ma.Summaries {
+			d := decimalsFor(s.Mean)
+			p("| %s | %s | %s | %s | %s |", ma.Metric.Name, names[j], commas(s.Mean, d), commas(s.CILow, d), commas(s.CIHigh, d))
+		}
+	}
+	p("")
+	p("### 8.2 Mean Differences vs %s (Welch)", names[0])
+	p("")
+	p("| Metric | Comparison | Point Estimate | 95%% CI Lower | 95%% CI Upper |")
+	p("|--------|------------|----------------|--------------|--------------|")
+	for _, ma := range exp.Metrics {
+		d := decimalsFor(ma.Summaries[0].Mean)
+		for j := 1; j < len(ma.Groups); j++ {
+			diff, lo, hi := WelchCI(ma.Groups[0], ma.Groups[j])
+			p("| %s Δ | %s - %s | %s | %s | %s |", ma.Metric.Name, names[j], names[0], signed(diff, d), signed(lo, d), signed(hi, d))
+		}
+	}
+	p("")
+	p("---")
+	p("")
+
+	// 9. Recommendations
+	p("## 9. Recommendations")
+	p("")
+	best := 0
+	for i, run := range exp.Runs {
+		if run.BalanceScore > exp.Runs[best].BalanceScore {
+			best = i
+		}
+	}
+	p("### 9.1 Best Variant")
+	p("")
+	p("**%s** has the highest balance score (%.1f / 100).", names[best], exp.Runs[best].BalanceScore)
+	p("")
+	p("### 9.2 Significant Changes vs %s", names[0])
+	p("")
+	changes := 0
+	for _, ma := range exp.Metrics {
+		for _, c := range ma.Tukey {
+			if c.A != 0 || c.P >= alpha {
+				continue
+			}
+			d := decimalsFor(ma.Summaries[0].Mean)
+			p("- %s: %s %s by %s (%s, p %s, d = %.2f)", names[c.B], ma.Metric.Name, direction(c.Diff),
+				commas(math.Abs(c.Diff), d), pctChange(ma.Summaries[0].Mean, ma.Summaries[c.B].Mean),
+				pCompare(c.P), CohensD(ma.Groups[0], ma.Groups[c.B]))
+			changes++
+		}
+	}
+	if changes == 0 {
+		p("- None. The variants are statistically indistinguishable at this sample size.")
+	}
+	p("")
+	p("### 9.3 Survival Target")
+	p("")
+	p("Target: 200-350 ticks/life")
+	p("")
+	for _, ma := range exp.Metrics {
+		if ma.Metric.Name != "Ticks/Life" {
+			continue
+		}
+		for j, s := range ma.Summaries {
+			verdict := "✅ within target"
+			if s.Mean < 200 {
+				verdict = "⚠️ below target - deaths come too quickly"
+			} else if s.Mean > 350 {
+				verdict = "⚠️ above target - lives last too long"
+			}
+			p("- %s: %.0f ticks/life, %s", names[j], s.Mean, verdict)
+		}
+	}
+	p("")
+	p("---")
+	p("")
+
+	// Appendix
+	p("## Appendix: Reproduction")
+	p("")
+	p("```bash")
+	run := exp.Runs[0]
+	cmd := fmt.Sprintf("go run ./cmd/balance -games %d -seed %d -width %d -height %d \\\n    -strategy %s -max-ticks %d -dodge %d -shoot %g \\\n    -depth %d -reaction %d -errors %g",
+		exp.Games, exp.BaseSeed, run.Width, run.Height, run.Strategy, run.MaxTicks,
+		run.Agent.DodgeRange, run.Agent.ShootChance, run.Agent.Depth, run.Agent.ReactionTicks, run.Agent.ErrorRate)
+	// Spell out everything that differs from the defaults, shared -set
+	// overrides included, so the command stands on its own
+	defaults := engine.DefaultConfig().Pairs()
+	for i, v := range exp.Variants {
+		var changed []string
+		for j, pair := range exp.Runs[i].Config {
+			if pair != defaults[j] {
+				changed = append(changed, pair)
+			}
+		}
+		cmd += fmt.Sprintf(" \\\n    -variant '%s:%s'", v.Name, strings.Join(changed, ","))
+	}
+	p("%s", cmd)
+	p("```")
+}
+
+func (exp Experiment) names() []string {
+	names := make([]string, len(exp.Variants))
+	for i, v := range exp.Variants {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// commas formats v with thousands separators, like 37,293
+func commas(v float64, decimals int) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return fmt.Sprint(v)
+	}
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString("." + frac)
+	}
+	return b.String()
+}
+
+// signed is commas with an explicit sign
+func signed(v float64, decimals int) string {
+	s := commas(v, decimals)
+	if !strings.HasPrefix(s, "-") {
+		s = "+" + s
+	}
+	return s
+}
+
+// decimalsFor picks a precision that suits the magnitude of a metric
+func decimalsFor(v float64) int {
+	if math.Abs(v) >= 100 {
+		return 0
+	}
+	return 2
+}
+
+func fStat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "∞"
+	}
+	return commas(f, 2)
+}
+
+func pValue(p float64) string {
+	if p < 0.0001 {
+		return "<0.0001"
+	}
+	return fmt.Sprintf("%.4f", p)
+}
+
+// pCompare is pValue phrased for "p < 0.0001" or "p = 0.0123"
+func pCompare(p float64) string {
+	if p < 0.0001 {
+		return "< 0.0001"
+	}
+	return fmt.Sprintf("= %.4f", p)
+}
+
+func significance(p float64) string {
+	switch {
+	case p < 0.001:
+		return "✅ Yes ***"
+	case p < 0.01:
+		return "✅ Yes **"
+	case p < alpha:
+		return "✅ Yes *"
+	}
+	return "❌ No"
+}
+
+func effectSize(d float64) string {
+	switch d = math.Abs(d); {
+	case d > 100:
+		return "Astronomical"
+	case d > 2:
+		return "Extremely Large"
+	case d >= 0.8:
+		return "Large"
+	case d >= 0.5:
+		return "Medium"
+	case d >= 0.2:
+		return "Small"
+	}
+	return "Negligible"
+}
+
+func direction(diff float64) string {
+	if diff < 0 {
+		return "decreased"
+	}
+	return "increased"
+}
+
+func pctChange(from, to float64) string {
+	if from == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%+.1f%%", (to-from)/math.Abs(from)*100)
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
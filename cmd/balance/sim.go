@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/michaellavery-grp/centipede/agent"
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// TestStats tracks metrics for a single game. Kills, shots, mushrooms and
+// deaths by cause come straight from the engine's telemetry.
+type TestStats struct {
+	Seed            int64 `json:"seed"`
+	Score           int   `json:"score"`
+	LivesLost       int   `json:"livesLost"`
+	LevelsCompleted int   `json:"levelsCompleted"`
+	TicksAlive      int   `json:"ticksAlive"`
+	FinalLevel      int   `json:"finalLevel"`
+	engine.Stats
+}
+
+// SimOptions configures every game in a simulation run
+type SimOptions struct {
+	Width, Height int
+	Config        engine.Config
+	Strategy      string        // Agent name, see agent.Names
+	Agent         agent.Options // Tuning for the built-in agents
+	MaxTicks      int           // Prevent infinite games
+}
+
+// DefaultSimOptions matches the original 1,000 game harness
+func DefaultSimOptions() SimOptions {
+	return SimOptions{
+		Width:    engine.DefaultWidth,
+		Height:   engine.DefaultHeight,
+		Config:   engine.DefaultConfig(),
+		Strategy: "heuristic",
+		Agent:    agent.DefaultOptions(),
+		MaxTicks: 10000,
+	}
+}
+
+// SimulateGame runs a single automated game with an AI agent.
+// The game and the agent both derive their random state from seed, so a seed
+// replays exactly and games can run on any goroutine without sharing random
+// state.
+func SimulateGame(seed int64, opts SimOptions) TestStats {
+	g := engine.NewGameWithConfig(opts.Width, opts.Height, seed, opts.Config)
+	bot, err := agent.New(opts.Strategy, agentSeed(seed), opts.Agent)
+	if err != nil {
+		panic(err) // Strategy names are validated up front
+	}
+	stats := TestStats{Seed: seed}
+
+	for tick := 0; tick < opts.MaxTicks && !g.GameOver(); tick++ {
+		stats.TicksAlive++
+		agent.Play(bot, g)
+		g.Step()
+
+		// Track statistics
+		if g.Level() > stats.FinalLevel {
+			stats.LevelsCompleted++
+			stats.FinalLevel = g.Level()
+		}
+	}
+
+	// Final stats
+	stats.Score = g.Score()
+	stats.Stats = g.Stats()
+	stats.LivesLost = stats.Deaths()
+
+	return stats
+}
+
+// agentSeed derives the agent's seed from the game's with a splitmix64
+// step. Seeding both from the same value would have the agent's random
+// choices move in lockstep with the game's spawns.
+func agentSeed(seed int64) int64 {
+	z := uint64(seed) + 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return int64(z ^ z>>31)
+}
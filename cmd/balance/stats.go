@@ -0,0 +1,452 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Statistics for comparing simulation runs. Everything here works on plain
+// float64 samples so any per-game metric can be tested the same way.
+
+// Summary describes one sample
+type Summary struct {
+	N                  int
+	Mean, Median, SD   float64
+	Min, Max           float64
+	Q1, Q3             float64
+	Skewness, Kurtosis float64 // Kurtosis is 3 for a normal distribution
+	CV                 float64 // SD / mean
+	CILow, CIHigh      float64 // 95% confidence interval of the mean
+	ShapiroW, ShapiroP float64
+	ShapiroOK          bool // False when n is outside 4..5000 or the sample is constant
+}
+
+// Summarize computes descriptive statistics for xs
+func Summarize(xs []float64) Summary {
+	s := Summary{N: len(xs)}
+	if s.N == 0 {
+		return s
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+
+	s.Mean = mean(xs)
+	s.SD = math.Sqrt(sampleVariance(xs))
+	s.Min, s.Max = sorted[0], sorted[s.N-1]
+	s.Median = quantile(sorted, 0.5)
+	s.Q1 = quantile(sorted, 0.25)
+	s.Q3 = quantile(sorted, 0.75)
+	if s.Mean != 0 {
+		s.CV = s.SD / math.Abs(s.Mean)
+	}
+
+	// Moment based shape statistics
+	var m2, m3, m4 float64
+	for _, x := range xs {
+		d := x - s.Mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	n := float64(s.N)
+	m2, m3, m4 = m2/n, m3/n, m4/n
+	if m2 > 0 {
+		s.Skewness = m3 / math.Pow(m2, 1.5)
+		s.Kurtosis = m4 / (m2 * m2)
+	}
+
+	if s.N > 1 {
+		half := tQuantile(0.975, n-1) * s.SD / math.Sqrt(n)
+		s.CILow, s.CIHigh = s.Mean-half, s.Mean+half
+	}
+	s.ShapiroW, s.ShapiroP, s.ShapiroOK = shapiroWilk(sorted)
+	return s
+}
+
+func mean(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+func sampleVariance(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	m := mean(xs)
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - m) * (x - m)
+	}
+	return ss / float64(len(xs)-1)
+}
+
+// quantile interpolates linearly between order statistics (R type 7)
+func quantile(sorted []float64, p float64) float64 {
+	h := p * float64(len(sorted)-1)
+	lo := int(math.Floor(h))
+	if lo+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// ANOVA is a one-way analysis of variance across groups
+type ANOVA struct {
+	SSB, SSW, SST float64 // Between, within and total sums of squares
+	DFB, DFW, DFT int
+	MSB, MSW      float64
+	F, P          float64
+	EtaSquared    float64 // Proportion of variance explained by the group
+}
+
+// OneWayANOVA tests whether all groups share the same mean
+func OneWayANOVA(groups [][]float64) ANOVA {
+	var a ANOVA
+	var all []float64
+	for _, g := range groups {
+		all = append(all, g...)
+	}
+	grand := mean(all)
+	for _, g := range groups {
+		m := mean(g)
+		a.SSB += float64(len(g)) * (m - grand) * (m - grand)
+		for _, x := range g {
+			a.SSW += (x - m) * (x - m)
+		}
+	}
+	a.SST = a.SSB + a.SSW
+	a.DFB = len(groups) - 1
+	a.DFW = len(all) - len(groups)
+	a.DFT = len(all) - 1
+	a.MSB = a.SSB / float64(a.DFB)
+	a.MSW = a.SSW / float64(a.DFW)
+
+	switch {
+	case a.MSW > 0:
+		a.F = a.MSB / a.MSW
+		a.P = fSurvival(a.F, float64(a.DFB), float64(a.DFW))
+	case a.MSB > 0:
+		// No spread inside groups but different means - as significant as it gets
+		a.F, a.P = math.Inf(1), 0
+	default:
+		a.P = 1
+	}
+	if a.SST > 0 {
+		a.EtaSquared = a.SSB / a.SST
+	}
+	return a
+}
+
+// Levene tests whether all groups share the same variance. It uses the
+// median centred (Brown-Forsythe) form, which holds up on skewed samples
+// like game scores.
+func Levene(groups [][]float64) ANOVA {
+	deviations := make([][]float64, len(groups))
+	for i, g := range groups {
+		sorted := append([]float64(nil), g...)
+		sort.Float64s(sorted)
+		med := quantile(sorted, 0.5)
+		deviations[i] = make([]float64, len(g))
+		for j, x := range g {
+			deviations[i][j] = math.Abs(x - med)
+		}
+	}
+	return OneWayANOVA(deviations)
+}
+
+// CohensD is the standardised mean difference of b over a, using the
+// pooled standard deviation
+func CohensD(a, b []float64) float64 {
+	na, nb := float64(len(a)), float64(len(b))
+	pooled := math.Sqrt(((na-1)*sampleVariance(a) + (nb-1)*sampleVariance(b)) / (na + nb - 2))
+	diff := mean(b) - mean(a)
+	if pooled == 0 {
+		if diff == 0 {
+			return 0
+		}
+		return math.Copysign(math.Inf(1), diff)
+	}
+	return diff / pooled
+}
+
+// Comparison is one pairwise Tukey HSD comparison, B minus A
+type Comparison struct {
+	A, B          int // Group indexes
+	Diff          float64
+	CILow, CIHigh float64 // 95% simultaneous confidence interval
+	P             float64
+}
+
+// TukeyHSD compares every pair of groups, controlling the family-wise error
+// rate. It uses the Tukey-Kramer form so group sizes may differ.
+func TukeyHSD(groups [][]float64, a ANOVA) []Comparison {
+	k := float64(len(groups))
+	df := float64(a.DFW)
+	crit := tukeyQuantile(0.95, k, df)
+
+	var out []Comparison
+	for i := 0; i < len(groups); i++ {
+		for j := i + 1; j < len(groups); j++ {
+			c := Comparison{A: i, B: j, Diff: mean(groups[j]) - mean(groups[i])}
+			se := math.Sqrt(a.MSW / 2 * (1/float64(len(groups[i])) + 1/float64(len(groups[j]))))
+			c.CILow, c.CIHigh = c.Diff-crit*se, c.Diff+crit*se
+			switch {
+			case se > 0:
+				c.P = 1 - tukeyCDF(math.Abs(c.Diff)/se, k, df)
+			case c.Diff != 0:
+				c.P = 0
+			default:
+				c.P = 1
+			}
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// WelchCI is the 95% confidence interval of mean(b) - mean(a) without
+// assuming equal variances
+func WelchCI(a, b []float64) (diff, low, high float64) {
+	na, nb := float64(len(a)), float64(len(b))
+	va, vb := sampleVariance(a)/na, sampleVariance(b)/nb
+	diff = mean(b) - mean(a)
+	se := math.Sqrt(va + vb)
+	if se == 0 {
+		return diff, diff, diff
+	}
+	df := (va + vb) * (va + vb) / (va*va/(na-1) + vb*vb/(nb-1))
+	half := tQuantile(0.975, df) * se
+	return diff, diff - half, diff + half
+}
+
+// shapiroWilk tests sorted for normality using Royston's (1992/1995)
+// approximation. It is defined for 4 <= n <= 5000.
+func shapiroWilk(sorted []float64) (w, p float64, ok bool) {
+	n := len(sorted)
+	if n < 4 || n > 5000 || sorted[0] == sorted[n-1] {
+		return 0, 0, false
+	}
+	nf := float64(n)
+
+	m := make([]float64, n)
+	summ2 := 0.0
+	for i := range m {
+		m[i] = normalQuantile((float64(i+1) - 0.375) / (nf + 0.25))
+		summ2 += m[i] * m[i]
+	}
+	u := 1 / math.Sqrt(nf)
+	a := make([]float64, n)
+	an := m[n-1]/math.Sqrt(summ2) + poly(u, 0, 0.221157, -0.147981, -2.071190, 4.434685, -2.706056)
+	if n > 5 {
+		an1 := m[n-2]/math.Sqrt(summ2) + poly(u, 0, 0.042981, -0.293762, -1.752461, 5.682633, -3.582633)
+		phi := (summ2 - 2*m[n-1]*m[n-1] - 2*m[n-2]*m[n-2]) / (1 - 2*an*an - 2*an1*an1)
+		for i := 2; i < n-2; i++ {
+			a[i] = m[i] / math.Sqrt(phi)
+		}
+		a[n-2], a[1] = an1, -an1
+	} else {
+		phi := (summ2 - 2*m[n-1]*m[n-1]) / (1 - 2*an*an)
+		for i := 1; i < n-1; i++ {
+			a[i] = m[i] / math.Sqrt(phi)
+		}
+	}
+	a[n-1], a[0] = an, -an
+
+	mu := mean(sorted)
+	num, den := 0.0, 0.0
+	for i, x := range sorted {
+		num += a[i] * x
+		den += (x - mu) * (x - mu)
+	}
+	w = math.Min(num*num/den, 1)
+
+	var z float64
+	if n <= 11 {
+		gamma := 0.459*nf - 2.273
+		mean := poly(nf, 0.5440, -0.39978, 0.025054, -0.0006714)
+		sd := math.Exp(poly(nf, 1.3822, -0.77857, 0.062767, -0.0020322))
+		z = (-math.Log(gamma-math.Log1p(-w)) - mean) / sd
+	} else {
+		ln := math.Log(nf)
+		mean := poly(ln, -1.5861, -0.31082, -0.083751, 0.0038915)
+		sd := math.Exp(poly(ln, -0.4803, -0.082676, 0.0030302))
+		z = (math.Log1p(-w) - mean) / sd
+	}
+	return w, 1 - normalCDF(z), true
+}
+
+// poly evaluates c[0] + c[1]x + c[2]x² + ...
+func poly(x float64, c ...float64) float64 {
+	sum := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		sum = sum*x + c[i]
+	}
+	return sum
+}
+
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+func normalPDF(z float64) float64 {
+	return math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+}
+
+// normalQuantile is the inverse of normalCDF
+func normalQuantile(p float64) float64 {
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
+
+// fSurvival is P(F > f) for an F distribution with d1, d2 degrees of freedom
+func fSurvival(f, d1, d2 float64) float64 {
+	if f <= 0 {
+		return 1
+	}
+	return regIncBeta(d2/2, d1/2, d2/(d2+d1*f))
+}
+
+// tCDF is P(T <= t) for a Student t distribution with df degrees of freedom
+func tCDF(t, df float64) float64 {
+	tail := 0.5 * regIncBeta(df/2, 0.5, df/(df+t*t))
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// tQuantile inverts tCDF by bisection
+func tQuantile(p, df float64) float64 {
+	return invert(func(t float64) float64 { return tCDF(t, df) }, p, -1e3, 1e3)
+}
+
+// invert finds x in [lo, hi] with cdf(x) = p for a non-decreasing cdf
+func invert(cdf func(float64) float64, p, lo, hi float64) float64 {
+	for i := 0; i < 100 && hi-lo > 1e-10; i++ {
+		mid := (lo + hi) / 2
+		if cdf(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// regIncBeta is the regularised incomplete beta function I_x(a, b)
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	// The continued fraction converges fastest on this side of the mean
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(a, b, x) / a
+	}
+	return 1 - front*betaFraction(b, a, 1-x)/b
+}
+
+// betaFraction evaluates the incomplete beta continued fraction (modified Lentz)
+func betaFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= 300; m++ {
+		mf := float64(m)
+		// Even step
+		num := mf * (b - mf) * x / ((a + 2*mf - 1) * (a + 2*mf))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		// Odd step
+		num = -(a + mf) * (a + b + mf) * x / ((a + 2*mf) * (a + 2*mf + 1))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-14 {
+			break
+		}
+	}
+	return h
+}
+
+// tukeyCDF is P(Q <= q) for the studentized range of k means with df error
+// degrees of freedom, by numerical integration
+func tukeyCDF(q, k, df float64) float64 {
+	if q <= 0 {
+		return 0
+	}
+	// Range of k standard normals scaled by s = sqrt(chi²(df)/df). The
+	// density of s is concentrated around 1 with spread ~1/sqrt(2df).
+	spread := 8 / math.Sqrt(2*df)
+	lo, hi := math.Max(0, 1-spread), 1+spread
+	lg, _ := math.Lgamma(df / 2)
+	logNorm := df/2*math.Log(df) - lg - (df/2-1)*math.Ln2
+	density := func(s float64) float64 {
+		if s <= 0 {
+			return 0
+		}
+		return math.Exp(logNorm + (df-1)*math.Log(s) - df*s*s/2)
+	}
+	return simpson(func(s float64) float64 {
+		return density(s) * rangeCDF(q*s, k)
+	}, lo, hi, 200)
+}
+
+// rangeCDF is P(R <= w) for the range R of k standard normals
+func rangeCDF(w, k float64) float64 {
+	if w <= 0 {
+		return 0
+	}
+	p := simpson(func(z float64) float64 {
+		return k * normalPDF(z) * math.Pow(normalCDF(z)-normalCDF(z-w), k-1)
+	}, -8, 8, 400)
+	return math.Min(p, 1)
+}
+
+// tukeyQuantile inverts tukeyCDF by bisection
+func tukeyQuantile(p, k, df float64) float64 {
+	return invert(func(q float64) float64 { return tukeyCDF(q, k, df) }, p, 0, 100)
+}
+
+// simpson integrates f over [a, b] with n (even) intervals
+func simpson(f func(float64) float64, a, b float64, n int) float64 {
+	h := (b - a) / float64(n)
+	sum := f(a) + f(b)
+	for i := 1; i < n; i++ {
+		x := a + float64(i)*h
+		if i%2 == 1 {
+			sum += 4 * f(x)
+		} else {
+			sum += 2 * f(x)
+		}
+	}
+	return sum * h / 3
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol
+}
+
+// Critical values from standard t tables
+func TestTQuantile(t *testing.T) {
+	tests := []struct {
+		p, df, want float64
+	}{
+		{0.975, 10, 2.228139},
+		{0.975, 30, 2.042272},
+		{0.95, 1, 6.313752},
+		{0.995, 5, 4.032143},
+		{0.5, 7, 0},
+		{0.025, 10, -2.228139},
+	}
+	for _, tt := range tests {
+		if got := tQuantile(tt.p, tt.df); !near(got, tt.want, 1e-4) {
+			t.Errorf("tQuantile(%g, %g) = %.6f, want %.6f", tt.p, tt.df, got, tt.want)
+		}
+	}
+}
+
+// Studentized range critical values from Tukey tables. With two groups the
+// range is sqrt(2) times |t|, so q(0.95; 2, df) = sqrt(2) t(0.975, df).
+func TestTukeyQuantile(t *testing.T) {
+	tests := []struct {
+		p, k, df, want, tol float64
+	}{
+		{0.95, 3, 20, 3.578, 2e-3},
+		{0.95, 4, 30, 3.845, 2e-3},
+		{0.95, 3, 15, 3.673, 2e-3},
+		{0.95, 2, 10, math.Sqrt2 * 2.228139, 2e-3},
+	}
+	for _, tt := range tests {
+		if got := tukeyQuantile(tt.p, tt.k, tt.df); !near(got, tt.want, tt.tol) {
+			t.Errorf("tukeyQuantile(%g, %g, %g) = %.4f, want %.4f", tt.p, tt.k, tt.df, got, tt.want)
+		}
+	}
+}
+
+// With d1 = 2 the F survival function has the closed form
+// (1 + 2f/d2)^(-d2/2); the others are 5% critical values from F tables.
+func TestFSurvival(t *testing.T) {
+	tests := []struct {
+		f, d1, d2, want float64
+	}{
+		{9.264706, 2, 15, math.Pow(1+2*9.264706/15, -7.5)},
+		{1, 2, 10, math.Pow(1+2.0/10, -5)},
+		{3.885294, 2, 12, 0.05},
+		{3.098391, 3, 20, 0.05},
+		{4.964603, 1, 10, 0.05},
+		{0, 3, 20, 1},
+	}
+	for _, tt := range tests {
+		if got := fSurvival(tt.f, tt.d1, tt.d2); !near(got, tt.want, 1e-6) {
+			t.Errorf("fSurvival(%g, %g, %g) = %.7f, want %.7f", tt.f, tt.d1, tt.d2, got, tt.want)
+		}
+	}
+}
+
+func TestRegIncBeta(t *testing.T) {
+	tests := []struct {
+		a, b, x, want float64
+	}{
+		{1, 1, 0.3, 0.3},                  // Uniform
+		{3, 1, 0.6, math.Pow(0.6, 3)},     // I_x(a, 1) = x^a
+		{1, 4, 0.2, 1 - math.Pow(0.8, 4)}, // I_x(1, b) = 1 - (1-x)^b
+		{7.5, 7.5, 0.5, 0.5},              // Symmetric
+		{0.5, 0.5, 0.25, 1.0 / 3},         // Arcsine: (2/pi) asin(sqrt(x))
+		{2, 3, 0, 0},
+		{2, 3, 1, 1},
+	}
+	for _, tt := range tests {
+		if got := regIncBeta(tt.a, tt.b, tt.x); !near(got, tt.want, 1e-9) {
+			t.Errorf("regIncBeta(%g, %g, %g) = %.10f, want %.10f", tt.a, tt.b, tt.x, got, tt.want)
+		}
+	}
+}
+
+// The worked one-way ANOVA example from Wikipedia's "One-way analysis of
+// variance" article
+var textbookGroups = [][]float64{
+	{6, 8, 4, 5, 3, 4},
+	{8, 12, 9, 11, 6, 8},
+	{13, 9, 11, 8, 7, 12},
+}
+
+func TestOneWayANOVA(t *testing.T) {
+	a := OneWayANOVA(textbookGroups)
+	if a.SSB != 84 || a.SSW != 68 || a.DFB != 2 || a.DFW != 15 {
+		t.Fatalf("SSB, SSW, DFB, DFW = %g, %g, %d, %d, want 84, 68, 2, 15", a.SSB, a.SSW, a.DFB, a.DFW)
+	}
+	if !near(a.F, 9.264706, 1e-6) {
+		t.Errorf("F = %.6f, want 9.264706", a.F)
+	}
+	if !near(a.P, 0.0023988, 1e-6) {
+		t.Errorf("P = %.7f, want 0.0023988", a.P)
+	}
+	if !near(a.EtaSquared, 84.0/152, 1e-12) {
+		t.Errorf("eta squared = %g, want %g", a.EtaSquared, 84.0/152)
+	}
+}
+
+// Brown-Forsythe on the same data: an ANOVA of the absolute deviations from
+// each group's median
+func TestLevene(t *testing.T) {
+	a := Levene(textbookGroups)
+	if !near(a.SSB, 4.0/3, 1e-9) || !near(a.SSW, 59.0/3, 1e-9) {
+		t.Errorf("SSB, SSW = %g, %g, want 4/3, 59/3", a.SSB, a.SSW)
+	}
+	if !near(a.F, 0.508475, 1e-6) || !near(a.P, 0.611415, 1e-6) {
+		t.Errorf("F, P = %.6f, %.6f, want 0.508475, 0.611415", a.F, a.P)
+	}
+}
+
+func TestTukeyHSD(t *testing.T) {
+	groups := textbookGroups
+	comps := TukeyHSD(groups, OneWayANOVA(groups))
+	if len(comps) != 3 {
+		t.Fatalf("%d comparisons, want 3", len(comps))
+	}
+	// se = sqrt(MSW / n) with MSW = 68/15 and n = 6; q(0.95; 3, 15) = 3.673
+	half := 3.673 * math.Sqrt(68.0/15/6)
+	tests := []struct {
+		diff        float64
+		significant bool
+	}{
+		{4, true},  // Group 2 - group 1
+		{5, true},  // Group 3 - group 1
+		{1, false}, // Group 3 - group 2
+	}
+	for i, tt := range tests {
+		c := comps[i]
+		if c.Diff != tt.diff {
+			t.Errorf("comparison %d diff = %g, want %g", i, c.Diff, tt.diff)
+		}
+		if !near(c.CIHigh-c.Diff, half, 5e-3) || !near(c.Diff-c.CILow, half, 5e-3) {
+			t.Errorf("comparison %d CI = [%g, %g], want %g ± %g", i, c.CILow, c.CIHigh, c.Diff, half)
+		}
+		if (c.P < 0.05) != tt.significant {
+			t.Errorf("comparison %d P = %g, want significant = %v", i, c.P, tt.significant)
+		}
+	}
+}
+
+// With equal sizes and variances Welch's df is 2(n-1)
+func TestWelchCI(t *testing.T) {
+	diff, low, high := WelchCI([]float64{1, 2, 3, 4, 5}, []float64{3, 4, 5, 6, 7})
+	half := 2.306004 // t(0.975, 8), se = 1
+	if diff != 2 || !near(low, diff-half, 1e-4) || !near(high, diff+half, 1e-4) {
+		t.Errorf("WelchCI = %g [%g, %g], want 2 [%g, %g]", diff, low, high, 2-half, 2+half)
+	}
+}
+
+// Shapiro and Wilk's (1965) example of the weights of 11 men. R's
+// shapiro.test, which uses the same Royston approximation, gives
+// W = 0.7888, p = 0.0067.
+func TestShapiroWilk(t *testing.T) {
+	weights := []float64{148, 154, 158, 160, 161, 162, 166, 170, 182, 195, 236}
+	w, p, ok := shapiroWilk(weights)
+	if !ok {
+		t.Fatal("test not run")
+	}
+	if !near(w, 0.7888, 1e-3) || !near(p, 0.0067, 1e-3) {
+		t.Errorf("W, p = %.4f, %.4f, want 0.7888, 0.0067", w, p)
+	}
+
+	// Expected normal order statistics look as normal as a sample can
+	normal := make([]float64, 50)
+	for i := range normal {
+		normal[i] = normalQuantile((float64(i+1) - 0.375) / 50.25)
+	}
+	if w, p, _ := shapiroWilk(normal); w < 0.99 || p < 0.5 {
+		t.Errorf("normal scores: W, p = %.4f, %.4f, want W > 0.99 and p > 0.5", w, p)
+	}
+}
+
+func TestDegenerateInputs(t *testing.T) {
+	t.Run("equal groups", func(t *testing.T) {
+		a := OneWayANOVA([][]float64{{1, 2, 3}, {1, 2, 3}})
+		if a.F != 0 || a.P != 1 {
+			t.Errorf("F, P = %g, %g, want 0, 1", a.F, a.P)
+		}
+		for _, c := range TukeyHSD([][]float64{{1, 2, 3}, {1, 2, 3}}, a) {
+			if c.Diff != 0 || !near(c.P, 1, 1e-6) {
+				t.Errorf("Tukey diff, P = %g, %g, want 0, 1", c.Diff, c.P)
+			}
+		}
+	})
+	t.Run("zero variance", func(t *testing.T) {
+		same := OneWayANOVA([][]float64{{2, 2}, {2, 2}})
+		if same.F != 0 || same.P != 1 || same.EtaSquared != 0 {
+			t.Errorf("identical constants: F, P, eta = %g, %g, %g, want 0, 1, 0", same.F, same.P, same.EtaSquared)
+		}
+		apart := OneWayANOVA([][]float64{{1, 1}, {2, 2}})
+		if !math.IsInf(apart.F, 1) || apart.P != 0 {
+			t.Errorf("different constants: F, P = %g, %g, want +Inf, 0", apart.F, apart.P)
+		}
+		if c := TukeyHSD([][]float64{{1, 1}, {2, 2}}, apart)[0]; c.P != 0 {
+			t.Errorf("Tukey P = %g, want 0", c.P)
+		}
+		if d := CohensD([]float64{1, 1}, []float64{2, 2}); !math.IsInf(d, 1) {
+			t.Errorf("Cohen's d = %g, want +Inf", d)
+		}
+		if diff, low, high := WelchCI([]float64{1, 1}, []float64{2, 2}); diff != 1 || low != 1 || high != 1 {
+			t.Errorf("WelchCI = %g [%g, %g], want 1 [1, 1]", diff, low, high)
+		}
+	})
+	t.Run("shapiro bounds", func(t *testing.T) {
+		for _, xs := range [][]float64{nil, {1}, {1, 2}, {1, 2, 3}, {5, 5, 5, 5, 5}} {
+			if _, _, ok := shapiroWilk(xs); ok {
+				t.Errorf("shapiroWilk(%v) ran, want ok = false", xs)
+			}
+		}
+	})
+}
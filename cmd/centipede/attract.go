@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/centipede/agent"
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Attract loop timing, in engine ticks (50ms at normal speed):
+// splash -> demo game -> high scores -> splash
+const (
+	splashTicks    = 100 // 5 seconds
+	demoTicks      = 600 // 30 seconds, or until the bot loses its last life
+	highScoreTicks = 100 // 5 seconds
+)
+
+// demoAgent plays the demo game. The human agent fumbles now and then, so
+// the demo looks like someone playing rather than a machine.
+const demoAgent = "human"
+
+// attracting reports whether the attract loop is running
+func (m model) attracting() bool {
+	return m.state == splashScreen || m.state == demoScreen || m.state == highScoreScreen
+}
+
+// stepAttract advances the attract loop one tick
+func (m model) stepAttract() model {
+	m.attractTicks++
+	switch m.state {
+	case splashScreen:
+		if m.attractTicks >= splashTicks {
+			m = m.startDemo()
+		}
+	case demoScreen:
+		agent.Play(m.demoBot, m.demo)
+		m.demo.Step()
+		if m.attractTicks >= demoTicks || m.demo.GameOver() || m.demo.Won() {
+			m.state = highScoreScreen
+			m.attractTicks = 0
+			m.demo, m.demoBot = nil, nil
+		}
+	case highScoreScreen:
+		if m.attractTicks >= highScoreTicks {
+			m.state = splashScreen
+			m.attractTicks = 0
+		}
+	}
+	return m
+}
+
+// startDemo begins a fresh bot game. It has its own seed, so the demo never
+// touches the game waiting for the player.
+func (m model) startDemo() model {
+	seed := newSeed()
+	bot, err := agent.New(demoAgent, seed, agent.DefaultOptions())
+	if err != nil {
+		// Built-in agent names can't fail; skip straight to the scores
+		m.state = highScoreScreen
+		m.attractTicks = 0
+		return m
+	}
+	m.state = demoScreen
+	m.attractTicks = 0
+	m.demo = m.newGame(seed)
+	m.demoBot = bot
+	return m
+}
+
+// renderDemo shows the bot's game with an invitation to play
+func (m model) renderDemo() string {
+	title, info, status := m.demoChrome(m.demo)
+	return m.frame(m.demo, title, info, status)
+}
+
+// demoChrome is the text around the demo's board: the title, the stats and
+// the flashing invitation
+func (m model) demoChrome(g *engine.Game) (title, info, status string) {
+	title = titleStyle.Render("🐛 CENTIPEDE 🐛")
+	banner := ""
+	if m.flashOn {
+		banner = flashStyle.Render(">>> DEMO - PRESS ANY KEY TO PLAY <<<")
+	}
+	return title, "\n" + renderStats(g), banner
+}
+
+// renderHighScores shows the high score table on its own
+func (m model) renderHighScores() string {
+	title := highScoreStyle.Render("═══ HIGH SCORES ═══")
+
+	var scoreLines []string
+	for i, score := range m.highScores {
+		if i >= 10 {
+			break
+		}
+		scoreLines = append(scoreLines,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render(
+				fmt.Sprintf("%2d. %-10s  %6d", i+1, score.Name, score.Score)))
+	}
+	if len(scoreLines) == 0 {
+		scoreLines = append(scoreLines,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("No scores yet - be the first!"))
+	}
+
+	pressKey := "                                  "
+	if m.flashOn {
+		pressKey = flashStyle.Render(">>> PRESS ANY KEY TO PLAY <<<")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		"",
+		"",
+		splashTitleStyle.Render("🐛 CENTIPEDE 🐛"),
+		"",
+		title,
+		"",
+		strings.Join(scoreLines, "\n"),
+		"",
+		"",
+		pressKey,
+	)
+}
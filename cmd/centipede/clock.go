@@ -0,0 +1,111 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// The simulation runs on a fixed timestep: frames arrive at the render rate
+// and the clock works out how many whole engine ticks have elapsed since the
+// last one. Game logic only ever sees whole ticks, so the game plays the
+// same however fast the terminal draws or how often keys repeat.
+const (
+	frameInterval = time.Second / 30 // Render rate
+	maxCatchUp    = 5                // Ticks run per frame at most, so a stall doesn't fast-forward
+
+	minSpeed  = 0.25
+	maxSpeed  = 4.0
+	speedStep = 0.25
+)
+
+type frameMsg time.Time
+
+func frameCmd() tea.Cmd {
+	return tea.Tick(frameInterval, func(t time.Time) tea.Msg {
+		return frameMsg(t)
+	})
+}
+
+// clock turns wall time into engine ticks. speed scales how much game time
+// passes per second: 2 runs twice as many ticks, 0.5 half as many.
+type clock struct {
+	speed float64
+	last  time.Time
+	acc   time.Duration // Scaled time not yet spent on a tick
+}
+
+func newClock(speed float64) clock {
+	return clock{speed: clampSpeed(speed)}
+}
+
+// advance reports how many ticks are due at now
+func (c *clock) advance(now time.Time) int {
+	if c.last.IsZero() {
+		c.last = now
+		return 0
+	}
+	c.acc += time.Duration(float64(now.Sub(c.last)) * c.speed)
+	c.last = now
+
+	ticks := int(c.acc / engine.TickDuration)
+	c.acc -= time.Duration(ticks) * engine.TickDuration
+	if ticks > maxCatchUp {
+		ticks = maxCatchUp
+		c.acc = 0
+	}
+	return ticks
+}
+
+// setSpeed changes the game speed without dropping or adding ticks
+func (c *clock) setSpeed(speed float64) {
+	c.speed = clampSpeed(speed)
+}
+
+func clampSpeed(speed float64) float64 {
+	if speed < minSpeed {
+		return minSpeed
+	}
+	if speed > maxSpeed {
+		return maxSpeed
+	}
+	return speed
+}
+
+// inputQueue holds the actions pressed since the last tick. They're applied
+// together just before the next Step, the same point a bot acts at. Each
+// action counts once per tick, so key repeat rates don't matter either, and
+// of opposite moves only the latest counts, so tapping left then right
+// within a tick moves right rather than cancelling out.
+type inputQueue []engine.Action
+
+// opposites pairs each move with the one that cancels it
+var opposites = map[engine.Action]engine.Action{
+	engine.ActionLeft:  engine.ActionRight,
+	engine.ActionRight: engine.ActionLeft,
+	engine.ActionUp:    engine.ActionDown,
+	engine.ActionDown:  engine.ActionUp,
+}
+
+func (q *inputQueue) push(a engine.Action) {
+	if opposite, ok := opposites[a]; ok {
+		*q = slices.DeleteFunc(*q, func(queued engine.Action) bool {
+			return queued == opposite
+		})
+	}
+	if slices.Contains(*q, a) {
+		return
+	}
+	*q = append(*q, a)
+}
+
+// flush applies the queued actions to g and empties the queue
+func (q *inputQueue) flush(g *engine.Game) {
+	for _, a := range *q {
+		g.Apply(a)
+	}
+	*q = (*q)[:0]
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+func TestInputQueuePush(t *testing.T) {
+	const (
+		left  = engine.ActionLeft
+		right = engine.ActionRight
+		up    = engine.ActionUp
+		down  = engine.ActionDown
+		shoot = engine.ActionShoot
+	)
+	tests := []struct {
+		pushed, want []engine.Action
+	}{
+		{[]engine.Action{left, left, shoot, shoot}, []engine.Action{left, shoot}},
+		{[]engine.Action{left, right}, []engine.Action{right}},
+		{[]engine.Action{right, shoot, left}, []engine.Action{shoot, left}},
+		{[]engine.Action{up, left, down, right}, []engine.Action{down, right}},
+		{[]engine.Action{left, right, left}, []engine.Action{left}},
+	}
+	for _, tt := range tests {
+		var q inputQueue
+		for _, a := range tt.pushed {
+			q.push(a)
+		}
+		if !slices.Equal(q, tt.want) {
+			t.Errorf("pushing %q queued %q, want %q", tt.pushed, q, tt.want)
+		}
+	}
+}
+
+// Keys pressed while paused, or just before pausing, aren't held for the
+// unpause
+func TestPausedKeysDropped(t *testing.T) {
+	m := initialModel(1, true, 1)
+	m.state = playingGame
+	m.game = engine.NewGame(engine.DefaultWidth, engine.DefaultHeight, 1)
+	press := func(key tea.KeyMsg) {
+		updated, _ := m.Update(key)
+		m = updated.(model)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyLeft})
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	if len(m.inputs) != 0 {
+		t.Errorf("pausing kept %q queued", m.inputs)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	press(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if len(m.inputs) != 0 {
+		t.Errorf("keys pressed while paused queued %q", m.inputs)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if !slices.Equal(m.inputs, inputQueue{engine.ActionRight}) {
+		t.Errorf("after unpausing queued %q, want R", m.inputs)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const highScoreFile = "highscores.txt"
+
+// High Score entry
+type HighScore struct {
+	Name  string
+	Score int
+}
+
+// High Score Management
+func loadHighScores() []HighScore {
+	scores := []HighScore{}
+	data, err := os.ReadFile(highScoreFile)
+	if err != nil {
+		return scores // Return empty if file doesn't exist
+	}
+
+	lines := strings.Split(string(data), "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, ",")
+		if len(parts) == 2 {
+			score, err := strconv.Atoi(parts[1])
+			if err == nil {
+				scores = append(scores, HighScore{Name: parts[0], Score: score})
+			}
+		}
+	}
+
+	// Sort by score descending
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+
+	return scores
+}
+
+func saveHighScore(name string, score int) error {
+	scores := loadHighScores()
+	scores = append(scores, HighScore{Name: name, Score: score})
+
+	// Sort by score descending
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+
+	// Keep top 10
+	if len(scores) > 10 {
+		scores = scores[:10]
+	}
+
+	// Write to file
+	var lines []string
+	for _, s := range scores {
+		lines = append(lines, fmt.Sprintf("%s,%d", s.Name, s.Score))
+	}
+
+	return os.WriteFile(highScoreFile, []byte(strings.Join(lines, "\n")), 0644)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Screen columns around the board: its side borders. The text above and
+// below the board is wrapped to the board's width, so it adds none.
+const chromeCols = 2
+
+// boardSize picks the board for a new game from the terminal size: as big
+// as fits with its chrome, within the min and max bounds. Before the first
+// window size message arrives the terminal size is unknown and the default
+// board is used.
+func (m model) boardSize() (width, height int) {
+	if m.width == 0 || m.height == 0 {
+		return engine.DefaultWidth, engine.DefaultHeight
+	}
+	width = clamp(m.width-chromeCols, engine.MinWidth, engine.MaxWidth)
+	// How tall the chrome is depends on how its text wraps at this width.
+	// A spare row leaves room for the stats wrapping once more as the score
+	// grows.
+	height = clamp(m.height-m.chromeRows(freshGame, width)-1, engine.MinHeight, engine.MaxHeight)
+	return width, height
+}
+
+// freshGame stands in for a game not started yet when measuring the chrome
+// around it: the stats of every new game read the same, whatever its board.
+// It is only ever read.
+var freshGame = engine.NewGame(engine.DefaultWidth, engine.DefaultHeight, 0)
+
+// newGame starts a game sized to the terminal
+func (m model) newGame(seed int64) *engine.Game {
+	width, height := m.boardSize()
+	return engine.NewGame(width, height, seed)
+}
+
+// chrome is the text around g's board on the current screen
+func (m model) chrome(g *engine.Game) (title, info, status string) {
+	if m.state == demoScreen {
+		return m.demoChrome(g)
+	}
+	return m.gameChrome(g)
+}
+
+// wrap word-wraps text to the width of a board this wide, borders included
+func wrap(width int, text string) string {
+	return lipgloss.NewStyle().Width(width + chromeCols).Render(text)
+}
+
+// chromeRows is how many rows g's screen takes besides the board, on a
+// board this wide: the title, the borders, the info wrapped to the board's
+// width and one status row. Further status rows are shown only if the
+// terminal has room.
+func (m model) chromeRows(g *engine.Game, width int) int {
+	title, info, _ := m.chrome(g)
+	return lipgloss.Height(title) + 2 + lipgloss.Height(wrap(width, info)) + 1
+}
+
+// frame lays out a game screen: the title, the board, then info and status
+// wrapped to the board's width. Status rows past the bottom of the terminal
+// are dropped, blank margin rows above the message first, so a long game
+// over summary is cut short rather than pushing the board off screen.
+func (m model) frame(g *engine.Game, title, info, status string) string {
+	info = wrap(g.Width(), info)
+	status = wrap(g.Width(), status)
+	if m.height > 0 {
+		room := m.height - lipgloss.Height(title) - g.Height() - 2 - lipgloss.Height(info)
+		rows := strings.Split(status, "\n")
+		for len(rows) > max(room, 1) && strings.TrimSpace(rows[0]) == "" {
+			rows = rows[1:]
+		}
+		status = strings.Join(rows[:clamp(room, 1, len(rows))], "\n")
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, title, m.board.render(g), info, status)
+}
+
+// needs is the terminal size the current screen takes: that of the game
+// being played or watched, or while nothing is running, of a game on the
+// smallest board
+func (m model) needs() (width, height int) {
+	g, width, height := freshGame, engine.MinWidth, engine.MinHeight
+	if shown := m.shownGame(); shown != nil {
+		g, width, height = shown, shown.Width(), shown.Height()
+	}
+	return width + chromeCols, height + m.chromeRows(g, width)
+}
+
+// tooSmall reports whether the terminal can't show what should be on
+// screen: the board of the game being played or watched, or the smallest
+// board while nothing is running. An unknown terminal size is assumed to
+// fit.
+func (m model) tooSmall() bool {
+	if m.width == 0 || m.height == 0 {
+		return false
+	}
+	width, height := m.needs()
+	return m.width < width || m.height < height
+}
+
+// shownGame is the game on screen, or nil on the splash and score screens
+func (m model) shownGame() *engine.Game {
+	switch {
+	case m.state == demoScreen:
+		return m.demo
+	case m.replay != nil, m.state == playingGame && !m.enteringName:
+		return m.game
+	}
+	return nil
+}
+
+// pauseIfTooSmall pauses a running game whose board no longer fits the
+// terminal, so nothing happens while the player can't see it
+func (m model) pauseIfTooSmall() model {
+	if m.paused || !m.tooSmall() {
+		return m
+	}
+	if m.replay != nil {
+		m.paused = true
+		return m
+	}
+	if m.state == playingGame && m.game != nil && !m.game.GameOver() && !m.game.Won() {
+		m.paused = true
+		m.game.Apply(engine.ActionPause)
+		m.inputs = m.inputs[:0]
+	}
+	return m
+}
+
+// renderTooSmall asks for a bigger terminal
+func (m model) renderTooSmall() string {
+	width, height := m.needs()
+	msg := lipgloss.JoinVertical(
+		lipgloss.Center,
+		gameOverStyle.Render("Terminal too small"),
+		fmt.Sprintf("Need %dx%d, have %dx%d", width, height, m.width, m.height),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+			"Enlarge the window to continue"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg)
+}
+
+// letterbox centers a view in a terminal bigger than it
+func (m model) letterbox(view string) string {
+	if m.width == 0 || m.height == 0 {
+		return view
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, view)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Every screen of a game, game over summary included, stays inside the
+// terminal it was sized for
+func TestViewFitsTerminal(t *testing.T) {
+	sizes := [][2]int{{32, 29}, {40, 32}, {60, 35}, {80, 24}, {80, 40}, {120, 50}}
+	for _, size := range sizes {
+		updated, _ := initialModel(7, true, 1).Update(tea.WindowSizeMsg{Width: size[0], Height: size[1]})
+		m := updated.(model)
+		m.state = playingGame
+		m.game = m.newGame(7)
+		m.replayFile = "replays/replay-20261017-120000-7.txt"
+		for tick := 0; tick < 100000 && !m.game.GameOver(); tick++ {
+			m.game.Step()
+			if tick%250 == 0 || m.game.GameOver() {
+				view := m.View()
+				if w, h := lipgloss.Width(view), lipgloss.Height(view); w > size[0] || h > size[1] {
+					t.Fatalf("%dx%d terminal, tick %d: view is %dx%d", size[0], size[1], tick, w, h)
+				}
+			}
+		}
+		if !m.game.GameOver() {
+			t.Fatalf("%dx%d terminal: game never ended", size[0], size[1])
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// styleID names one of the board's styles, indexing boardStyles. The
+// renderer turns each into escape sequences once rather than asking
+// lipgloss for every cell.
+type styleID uint8
+
+const (
+	styleNone styleID = iota
+	stylePlayer
+	styleHead
+	styleBody
+	styleMushroom
+	stylePoison
+	styleBullet
+	styleFly
+	styleWing
+	styleFlea
+	styleSpider
+	styleScorpion
+	styleExplosion
+)
+
+var boardStyles = [...]lipgloss.Style{
+	styleNone:      lipgloss.NewStyle(),
+	stylePlayer:    playerStyle,
+	styleHead:      centipedeHeadStyle,
+	styleBody:      centipedeBodyStyle,
+	styleMushroom:  mushroomStyle,
+	stylePoison:    poisonMushroomStyle,
+	styleBullet:    bulletStyle,
+	styleFly:       flyStyle,
+	styleWing:      wingStyle,
+	styleFlea:      fleaStyle,
+	styleSpider:    spiderStyle,
+	styleScorpion:  scorpionStyle,
+	styleExplosion: explosionStyle,
+}
+
+// look is how one board cell is drawn
+type look struct {
+	glyph rune
+	style styleID
+}
+
+// looks maps each kind of cell to its glyph and style. Kinds whose look
+// depends on their state are refined by lookOf.
+var looks = [...]look{
+	engine.KindEmpty:     {' ', styleNone},
+	engine.KindPlayer:    {'A', stylePlayer},
+	engine.KindMushroom:  {'M', styleMushroom},
+	engine.KindWing:      {'~', styleWing},
+	engine.KindFly:       {'✺', styleFly},
+	engine.KindFlea:      {'┃', styleFlea}, // Vertical bar, falling
+	engine.KindScorpion:  {'§', styleScorpion},
+	engine.KindSpider:    {'Ж', styleSpider},
+	engine.KindSegment:   {'O', styleBody},
+	engine.KindExplosion: {'✶', styleExplosion},
+	engine.KindBullet:    {'|', styleBullet},
+}
+
+var (
+	headLook   = look{'@', styleHead}
+	poisonLook = look{'X', stylePoison} // Skull/poison symbol
+
+	// Indexed by health, frame and trail distance
+	mushroomGlyphs  = [...]rune{'.', '.', '*', 'm', 'M'}
+	explosionGlyphs = [...]rune{'✶', '✸', '✹', '✺'}
+	wingGlyphs      = [...]rune{'~', '~', '.'}
+)
+
+// lookOf picks the glyph and style for a cell from its kind and state
+func lookOf(c engine.Cell) look {
+	if int(c.Kind) >= len(looks) {
+		return looks[engine.KindEmpty]
+	}
+	l := looks[c.Kind]
+	switch c.Kind {
+	case engine.KindMushroom:
+		if c.Poisoned {
+			return poisonLook
+		}
+		l.glyph = mushroomGlyphs[clamp(c.Health, 0, len(mushroomGlyphs)-1)]
+	case engine.KindSegment:
+		if c.Head {
+			return headLook
+		}
+	case engine.KindExplosion:
+		l.glyph = explosionGlyphs[clamp(c.Frame, 0, len(explosionGlyphs)-1)]
+	case engine.KindWing:
+		l.glyph = wingGlyphs[clamp(c.Trail, 0, len(wingGlyphs)-1)]
+	}
+	return l
+}
+
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
@@ -0,0 +1,686 @@
+// Centipede - A terminal-based centipede game using Bubble Tea
+// Created by Claude Code (Anthropic)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/centipede/agent"
+	"github.com/michaellavery-grp/centipede/engine"
+	"github.com/michaellavery-grp/centipede/env"
+)
+
+const replayDir = "replays"
+
+// replayFileName builds a unique path for a finished game's replay
+func replayFileName(seed int64) string {
+	return filepath.Join(replayDir,
+		fmt.Sprintf("replay-%s-%d.txt", time.Now().Format("20060102-150405"), seed))
+}
+
+// Bubble Tea Model
+type gameState int
+
+const (
+	splashScreen gameState = iota
+	playingGame
+	gameOverScreen
+	demoScreen      // Attract mode: a bot plays while nobody is at the keys
+	highScoreScreen // Attract mode: the high score table on its own
+)
+
+type model struct {
+	game         *engine.Game
+	seed         int64 // Seed for the next game
+	fixedSeed    bool  // Seed came from --seed, so restarts replay it
+	paused       bool
+	width        int
+	height       int
+	state        gameState
+	flashOn      bool
+	clock        clock          // Paces engine ticks, see clock.go
+	inputs       inputQueue     // Actions waiting for the next tick
+	board        *boardRenderer // Draws the board, shared by every copy of the model
+	highScores   []HighScore
+	playerName   string
+	enteringName bool
+	scoreSaved   bool
+	replayFile   string // Where the finished game's replay was written
+	replayErr    error  // Set if writing the replay failed
+
+	// Playback of a --replay file
+	replay      *engine.Replay // nil when playing live
+	replayPos   int            // Next input to feed into the game
+	fastForward bool
+
+	// Attract loop shown until someone presses a key
+	attractTicks int          // Ticks spent on the current attract screen
+	demo         *engine.Game // The bot's game on the demo screen
+	demoBot      agent.Agent
+}
+
+// Styles
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("205")).
+			MarginBottom(1)
+
+	splashTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("10"))
+
+	flashStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("11"))
+
+	highScoreStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("14")).
+			Bold(true)
+
+	playerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("10"))
+
+	centipedeHeadStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("13"))
+
+	centipedeBodyStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("93"))
+
+	mushroomStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2"))
+
+	poisonMushroomStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("201")).
+				Bold(true)
+
+	bulletStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11"))
+
+	flyStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("208"))
+
+	spiderStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("51")).
+			Bold(true)
+
+	scorpionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("202")).
+			Bold(true)
+
+	fleaStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("226")).
+			Bold(true)
+
+	wingStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	explosionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+
+	statsStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("86")).
+			Bold(true)
+
+	gameOverStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("196")).
+			MarginTop(1).
+			MarginBottom(1)
+
+	winStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("10")).
+			MarginTop(1).
+			MarginBottom(1)
+)
+
+// newSeed picks a fresh wall-clock seed for games started without --seed
+func newSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+func initialModel(seed int64, fixedSeed bool, speed float64) model {
+	// The game itself is created when the player starts, once the terminal
+	// size is known
+	return model{
+		seed:       seed,
+		fixedSeed:  fixedSeed,
+		state:      splashScreen,
+		clock:      newClock(speed),
+		board:      newBoardRenderer(),
+		highScores: loadHighScores(),
+	}
+}
+
+// replayModel plays back a recorded game instead of taking live input
+func replayModel(r engine.Replay, speed float64) model {
+	return model{
+		game:       r.NewGame(),
+		seed:       r.Seed,
+		fixedSeed:  true,
+		state:      playingGame,
+		clock:      newClock(speed),
+		board:      newBoardRenderer(),
+		highScores: loadHighScores(),
+		replay:     &r,
+	}
+}
+
+// stepReplay feeds the recorded inputs for the next tick into the game and
+// advances it one tick. Pause inputs are skipped - playback never stalls.
+func (m *model) stepReplay() {
+	for m.replayPos < len(m.replay.Inputs) && m.replay.Inputs[m.replayPos].Tick <= m.game.Tick() {
+		if a := m.replay.Inputs[m.replayPos].Action; a != engine.ActionPause {
+			m.game.Apply(a)
+		}
+		m.replayPos++
+	}
+	m.game.Step()
+}
+
+// updateReplay handles keys while watching a replay
+func (m model) updateReplay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "f":
+		m.fastForward = !m.fastForward
+	case "r", "home":
+		// Rewind to start
+		m.game = m.replay.NewGame()
+		m.replayPos = 0
+	case "p", " ":
+		m.paused = !m.paused
+	case "+", "=":
+		m.clock.setSpeed(m.clock.speed + speedStep)
+	case "-":
+		m.clock.setSpeed(m.clock.speed - speedStep)
+	}
+	return m, nil
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(
+		frameCmd(),
+		tea.EnterAltScreen,
+	)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// The board keeps its size for the whole game; a terminal that
+		// shrinks below it pauses the game until it is big enough again
+		m.width = msg.Width
+		m.height = msg.Height
+		m = m.pauseIfTooSmall()
+
+	case tea.KeyMsg:
+		// Only quitting works while the too-small screen is up
+		if m.tooSmall() {
+			if s := msg.String(); s == "q" || s == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.replay != nil {
+			return m.updateReplay(msg)
+		}
+
+		// Any key on the splash screen or attract loop starts a real game
+		if m.attracting() {
+			m.state = playingGame
+			m.demo, m.demoBot = nil, nil
+			m.game = m.newGame(m.seed)
+			return m, nil
+		}
+
+		// Handle name entry
+		if m.enteringName {
+			switch msg.String() {
+			case "enter":
+				if m.playerName != "" {
+					saveHighScore(m.playerName, m.game.Score())
+					m.highScores = loadHighScores()
+					m.scoreSaved = true
+					m.enteringName = false
+				}
+			case "backspace":
+				if len(m.playerName) > 0 {
+					m.playerName = m.playerName[:len(m.playerName)-1]
+				}
+			default:
+				if len(msg.String()) == 1 && len(m.playerName) < 10 {
+					m.playerName += msg.String()
+				}
+			}
+			return m, nil
+		}
+
+		// Handle game controls
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "r":
+			// Restart game - allow restart when game is over
+			if m.game.GameOver() || m.game.Won() {
+				if !m.fixedSeed {
+					m.seed = newSeed()
+				}
+				m.game = m.newGame(m.seed)
+				m.inputs = m.inputs[:0]
+				m.state = playingGame
+				m.enteringName = false
+				m.scoreSaved = false
+				m.playerName = ""
+				m.replayFile = ""
+				m.replayErr = nil
+				return m, nil
+			}
+		// Moves and shots wait for the next tick. Holding space fires on
+		// key repeat, at most one shot per tick.
+		case "left", "a":
+			if m.steering() {
+				m.inputs.push(engine.ActionLeft)
+			}
+		case "right", "d":
+			if m.steering() {
+				m.inputs.push(engine.ActionRight)
+			}
+		case "up", "w":
+			if m.steering() {
+				m.inputs.push(engine.ActionUp)
+			}
+		case "down", "s":
+			if m.steering() {
+				m.inputs.push(engine.ActionDown)
+			}
+		case " ": // Spacebar
+			if m.steering() {
+				m.inputs.push(engine.ActionShoot)
+			}
+		case "+", "=":
+			m.clock.setSpeed(m.clock.speed + speedStep)
+		case "-":
+			m.clock.setSpeed(m.clock.speed - speedStep)
+		case "p":
+			if m.state == playingGame && !m.game.GameOver() && !m.game.Won() {
+				m.paused = !m.paused
+				m.game.Apply(engine.ActionPause)
+				// Keys pressed before pausing don't carry over to the unpause
+				m.inputs = m.inputs[:0]
+			}
+		}
+
+	case frameMsg:
+		// Run however many fixed ticks are due; View draws once per frame
+		ticks := m.clock.advance(time.Time(msg))
+		for i := 0; i < ticks; i++ {
+			m = m.tick()
+		}
+		return m, frameCmd()
+	}
+
+	return m, nil
+}
+
+// steering reports whether moves and shots go to the game: one is being
+// played, isn't over and isn't paused
+func (m model) steering() bool {
+	return m.state == playingGame && !m.paused && !m.game.GameOver() && !m.game.Won()
+}
+
+// tick advances whatever is on screen by one engine tick
+func (m model) tick() model {
+	// Flash "Press any key" message
+	m.flashOn = !m.flashOn
+
+	if m.replay != nil {
+		if !m.paused {
+			steps := 1
+			if m.fastForward {
+				steps = 8
+			}
+			for i := 0; i < steps && !m.game.GameOver() && !m.game.Won(); i++ {
+				m.stepReplay()
+			}
+		}
+		return m
+	}
+
+	if m.attracting() {
+		return m.stepAttract()
+	}
+
+	if m.state == playingGame && !m.paused {
+		m.inputs.flush(m.game)
+		m.game.Step()
+
+		// Write the replay as soon as the game ends
+		if (m.game.GameOver() || m.game.Won()) && m.replayFile == "" && m.replayErr == nil {
+			path := replayFileName(m.game.Seed())
+			if err := engine.SaveReplay(path, m.game.Replay()); err != nil {
+				m.replayErr = err
+			} else {
+				m.replayFile = path
+			}
+		}
+
+		// Check if game ended and score is high enough
+		if (m.game.GameOver() || m.game.Won()) && !m.scoreSaved && !m.enteringName {
+			scores := m.highScores
+			if len(scores) < 10 || m.game.Score() > scores[len(scores)-1].Score {
+				m.enteringName = true
+			}
+		}
+	}
+	return m
+}
+
+func (m model) View() string {
+	if m.tooSmall() {
+		return m.renderTooSmall()
+	}
+	return m.letterbox(m.screen())
+}
+
+// screen draws whatever is showing, at its natural size
+func (m model) screen() string {
+	switch m.state {
+	case splashScreen:
+		return m.renderSplash()
+	case demoScreen:
+		return m.renderDemo()
+	case highScoreScreen:
+		return m.renderHighScores()
+	}
+
+	if m.enteringName {
+		return m.renderNameEntry()
+	}
+
+	title, info, status := m.gameChrome(m.game)
+	return m.frame(m.game, title, info, status)
+}
+
+// gameChrome is the text around a game's board: the title above it, and
+// below it the stats and controls, then the status, which runs to several
+// lines once the game is over
+func (m model) gameChrome(g *engine.Game) (title, info, status string) {
+	title = titleStyle.Render("🐛 CENTIPEDE 🐛")
+
+	// Controls
+	controls := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf(
+		"[←→ or A/D] Move  [↑↓ or W/S] Up/Down  [Space] RAPID FIRE!  [P] Pause  [+/-] Speed x%g  [Q] Quit",
+		m.clock.speed))
+
+	// Status messages
+	if g.Respawning() {
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196")).
+			Bold(true).
+			Render(fmt.Sprintf("💥 RESPAWNING... %d", g.RespawnTimer()/10))
+	} else if m.paused {
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11")).
+			Bold(true).
+			Render("⏸  PAUSED")
+	}
+	if g.GameOver() {
+		status = gameOverStyle.Render(fmt.Sprintf(
+			"💥 GAME OVER! Press [R] to restart  (seed %d)", g.Seed())) +
+			"\n" + renderSummary(g)
+	}
+	if g.Won() {
+		status = winStyle.Render(fmt.Sprintf(
+			"🎉 YOU WIN! Press [R] to play again  (seed %d)", g.Seed()))
+	}
+	if m.replayFile != "" {
+		status += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+			"Replay saved to "+m.replayFile)
+	} else if m.replayErr != nil {
+		status += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(
+			fmt.Sprintf("Could not save replay: %v", m.replayErr))
+	}
+
+	// Replay playback replaces the live controls and status
+	if m.replay != nil {
+		controls = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf(
+			"[F] Fast-forward  [R] Rewind to start  [P] Pause  [+/-] Speed x%g  [Q] Quit",
+			m.clock.speed))
+
+		speed := "▶ REPLAY"
+		if m.paused {
+			speed = "⏸  REPLAY PAUSED"
+		} else if m.fastForward {
+			speed = "⏩ REPLAY x8"
+		}
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("14")).
+			Bold(true).
+			Render(fmt.Sprintf("%s  |  Tick: %d  |  Seed: %d", speed, g.Tick(), g.Seed()))
+		if g.GameOver() || g.Won() {
+			status += "\n" + gameOverStyle.Render("🏁 END OF REPLAY - [R] to watch again") +
+				"\n" + renderSummary(g)
+		}
+	}
+
+	return title, "\n" + renderStats(g) + "\n" + controls, status
+}
+
+// renderStats draws the status line under the board
+func renderStats(g *engine.Game) string {
+	// Stats with active flies count
+	activeBullets := 0
+	for _, b := range g.Bullets() {
+		if b.Active {
+			activeBullets++
+		}
+	}
+	activeFlies := 0
+	for _, f := range g.Flies() {
+		if f.Active {
+			activeFlies++
+		}
+	}
+
+	// Create lives display
+	livesStr := ""
+	for i := 0; i < g.Lives(); i++ {
+		livesStr += "♥"
+	}
+
+	return statsStyle.Render(fmt.Sprintf(
+		"Score: %d  |  Lives: %s  |  Bullets: %d  |  Segments: %d  |  Flies: %d  |  Level: %d",
+		g.Score(), livesStr, activeBullets, g.SegmentCount(), activeFlies, g.Level()))
+}
+
+// renderSummary is the end-of-game breakdown from the engine's telemetry
+func renderSummary(g *engine.Game) string {
+	s := g.Stats()
+	levels := make([]string, len(s.LevelTicks))
+	for i, t := range s.LevelTicks {
+		levels[i] = fmt.Sprint(t)
+	}
+	if len(levels) == 0 {
+		levels = append(levels, "none cleared")
+	}
+	lines := []string{
+		fmt.Sprintf("Shots: %d fired, %d hit (%.1f%%)  |  Bonus lives: %d",
+			s.ShotsFired, s.ShotsHit, s.Accuracy()*100, s.BonusLives),
+		fmt.Sprintf("Kills: %d heads, %d body, %d flies, %d fleas, %d spiders, %d scorpions",
+			s.HeadKills, s.BodyKills, s.FlyKills, s.FleaKills, s.SpiderKills, s.ScorpionKills),
+		fmt.Sprintf("Mushrooms: %d destroyed, %d created, %d poisoned, %d eaten by spiders",
+			s.MushroomsDestroyed, s.MushroomsCreated, s.MushroomsPoisoned, s.MushroomsEaten),
+		fmt.Sprintf("Deaths: %d centipede, %d escape, %d flea, %d spider  (%d off poison chutes)",
+			s.DeathsByCentipede, s.DeathsByEscape, s.DeathsByFlea, s.DeathsBySpider, s.ChuteDeaths),
+		"Ticks per level: " + strings.Join(levels, ", "),
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("250")).Render(strings.Join(lines, "\n"))
+}
+
+func (m model) renderSplash() string {
+	centipede := splashTitleStyle.Render(`
+   _____ ______ _   _ _______ _____ _____  ______ _____  ______
+  / ____|  ____| \ | |__   __|_   _|  __ \|  ____|  __ \|  ____|
+ | |    | |__  |  \| |  | |    | | | |__) | |__  | |  | | |__
+ | |    |  __| | . \ |  | |    | | |  ___/|  __| | |  | |  __|
+ | |____| |____| |\  |  | |   _| |_| |    | |____| |__| | |____
+  \_____|______|_| \_|  |_|  |_____|_|    |______|_____/|______|
+`)
+
+	worm := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render(`
+        ╔═══════════════════════════════════════╗
+        ║    @OOOOOOOOOOOOOO    Green Worm     ║
+        ║                                       ║
+        ║    ╱╲  ╱╲  ╱╲                        ║
+        ║   ╱  ╲╱  ╲╱  ╲       Spider          ║
+        ║  ╱    ╲    ╲  ╲                      ║
+        ║                                       ║
+        ║    ┃                 Flea             ║
+        ║    ●                                  ║
+        ║    ┃                                  ║
+        ║                                       ║
+        ║    ✺~.  Fly (200 pts!)                ║
+        ╚═══════════════════════════════════════╝
+`)
+
+	// High scores
+	highScoreTitle := highScoreStyle.Render("\n═══ HIGH SCORES ═══\n")
+	var scoreLines []string
+	for i, score := range m.highScores {
+		if i >= 10 {
+			break
+		}
+		scoreLines = append(scoreLines,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render(
+				fmt.Sprintf("%2d. %-10s  %6d", i+1, score.Name, score.Score)))
+	}
+	highScoreList := strings.Join(scoreLines, "\n")
+
+	// Flashing "Press any key"
+	pressKey := ""
+	if m.flashOn {
+		pressKey = flashStyle.Render("\n\n>>> PRESS ANY KEY TO CONTINUE <<<")
+	} else {
+		pressKey = "\n\n                                  "
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		centipede,
+		worm,
+		highScoreTitle,
+		highScoreList,
+		pressKey,
+	)
+}
+
+func (m model) renderNameEntry() string {
+	title := gameOverStyle.Render("NEW HIGH SCORE!")
+	scoreText := statsStyle.Render(fmt.Sprintf("Your Score: %d", m.game.Score()))
+	seedText := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+		fmt.Sprintf("Seed: %d", m.game.Seed()))
+	prompt := lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render(
+		"Enter your name (max 10 chars):")
+	nameDisplay := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("10")).
+		Bold(true).
+		Render(m.playerName + "_")
+	instruction := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+		"Press [Enter] to save")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		"",
+		"",
+		"",
+		title,
+		"",
+		scoreText,
+		seedText,
+		"",
+		prompt,
+		nameDisplay,
+		"",
+		instruction,
+	)
+}
+
+// options is what the command line asked for
+type options struct {
+	seed       int64
+	fixedSeed  bool    // --seed was given; otherwise seed is a fresh random one
+	replayPath string  // Play back this replay instead of a live game
+	envMode    bool    // Serve the engine headless over stdin/stdout
+	speed      float64 // Game speed multiplier
+}
+
+// parseFlags reads the command line
+func parseFlags() options {
+	var opts options
+	flag.Int64Var(&opts.seed, "seed", 0, "random seed for reproducible games (default: random)")
+	flag.StringVar(&opts.replayPath, "replay", "", "play back a recorded replay file")
+	flag.BoolVar(&opts.envMode, "env", false, "run headless as a JSON-lines RL environment on stdin/stdout")
+	flag.Float64Var(&opts.speed, "speed", 1, fmt.Sprintf("game speed multiplier, %g to %g", minSpeed, maxSpeed))
+	flag.Parse()
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			opts.fixedSeed = true
+		}
+	})
+	if !opts.fixedSeed {
+		opts.seed = newSeed()
+	}
+	return opts
+}
+
+// startModel builds the model for the mode selected on the command line
+func startModel(opts options) (model, error) {
+	if opts.replayPath == "" {
+		return initialModel(opts.seed, opts.fixedSeed, opts.speed), nil
+	}
+	r, err := engine.LoadReplay(opts.replayPath)
+	if err != nil {
+		return model{}, err
+	}
+	return replayModel(r, opts.speed), nil
+}
+
+func main() {
+	opts := parseFlags()
+	if opts.envMode {
+		if err := env.Serve(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	m, err := startModel(opts)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := tea.NewProgram(
+		m,
+		tea.WithAltScreen(),
+	)
+
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error: %v", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// styleSeq is a board style as raw escape sequences: on starts it, off
+// resets it
+type styleSeq struct {
+	on, off string
+}
+
+// boardRenderer draws a game's playfield with colored glyphs. It keeps the
+// last frame around, so only rows whose cells changed are drawn again, and
+// draws runs of same-styled cells under a single escape sequence.
+type boardRenderer struct {
+	seqs  []styleSeq      // boardStyles as escape sequences, built on first use
+	cells [][]engine.Cell // Board being drawn this frame
+	prev  [][]engine.Cell // Last frame's board
+	rows  []string        // Last frame's drawn rows, borders included
+	edge  string          // Top border's fill, redrawn when the width changes
+	width int
+	frame strings.Builder
+	row   strings.Builder
+}
+
+func newBoardRenderer() *boardRenderer {
+	return &boardRenderer{}
+}
+
+// render draws g's board
+func (r *boardRenderer) render(g *engine.Game) string {
+	if r.seqs == nil {
+		r.seqs = styleSeqs()
+	}
+	r.cells = g.DrawBoard(r.cells)
+	if len(r.rows) != len(r.cells) || r.width != g.Width() {
+		// New size: nothing from the last frame can be reused
+		r.rows = make([]string, len(r.cells))
+		r.prev = nil
+		r.width = g.Width()
+		r.edge = lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Render(
+			lipgloss.PlaceHorizontal(r.width, lipgloss.Center, ""))
+	}
+
+	size := r.frame.Len()
+	r.frame.Reset()
+	r.frame.Grow(size)
+	r.frame.WriteString("┌" + r.edge + "┐\n")
+	for y, row := range r.cells {
+		if r.prev == nil || !slices.Equal(row, r.prev[y]) {
+			r.rows[y] = r.renderRow(row)
+		}
+		r.frame.WriteString(r.rows[y])
+		r.frame.WriteByte('\n')
+	}
+	r.frame.WriteString("└" + r.edge + "┘")
+
+	r.cells, r.prev = r.prev, r.cells
+	return r.frame.String()
+}
+
+// renderRow draws one row of cells between the side borders
+func (r *boardRenderer) renderRow(row []engine.Cell) string {
+	r.row.Reset()
+	r.row.Grow(len(row) * 8)
+	r.row.WriteString("│")
+	current := styleNone
+	for _, cell := range row {
+		l := lookOf(cell)
+		if l.style != current {
+			r.row.WriteString(r.seqs[current].off)
+			r.row.WriteString(r.seqs[l.style].on)
+			current = l.style
+		}
+		r.row.WriteRune(l.glyph)
+	}
+	r.row.WriteString(r.seqs[current].off)
+	r.row.WriteString("│")
+	return r.row.String()
+}
+
+// styleSeqs renders a marker in each board style and splits the result
+// around it to get the style's escape sequences. The sequences depend on
+// the terminal's color profile, so this runs at the first frame rather
+// than at startup.
+func styleSeqs() []styleSeq {
+	seqs := make([]styleSeq, len(boardStyles))
+	for i, s := range boardStyles {
+		out := s.Render("x")
+		at := strings.Index(out, "x")
+		seqs[i] = styleSeq{on: out[:at], off: out[at+1:]}
+	}
+	return seqs
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// benchFrames records a game's board at every tick of a scripted session,
+// so each renderer draws the same sequence of frames
+func benchFrames(b *testing.B, n int) []*engine.Game {
+	b.Helper()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	c := engine.DefaultConfig()
+	c.StartingLives = 1000
+	g := engine.NewGameWithConfig(50, 28, 42, c)
+	frames := make([]*engine.Game, n)
+	for i := range frames {
+		g.Apply(engine.ActionShoot)
+		if i%40 < 20 {
+			g.Apply(engine.ActionLeft)
+		} else {
+			g.Apply(engine.ActionRight)
+		}
+		g.Step()
+		frames[i] = g.Clone()
+	}
+	return frames
+}
+
+// renderBoardPerCell is the renderer this package used to have: one
+// lipgloss Render per cell, concatenated onto a string
+func renderBoardPerCell(g *engine.Game) string {
+	board := g.GetBoard()
+	edge := lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Render(
+		lipgloss.PlaceHorizontal(len(board[0]), lipgloss.Center, ""))
+
+	boardStr := "┌" + edge + "┐\n"
+	for _, row := range board {
+		boardStr += "│"
+		for _, cell := range row {
+			l := lookOf(cell)
+			boardStr += boardStyles[l.style].Render(string(l.glyph))
+		}
+		boardStr += "│\n"
+	}
+	return boardStr + "└" + edge + "┘"
+}
+
+func BenchmarkRenderBoardPerCell(b *testing.B) {
+	frames := benchFrames(b, 200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		renderBoardPerCell(frames[i%len(frames)])
+	}
+}
+
+// BenchmarkRenderBoard draws a new tick every frame
+func BenchmarkRenderBoard(b *testing.B) {
+	frames := benchFrames(b, 200)
+	r := newBoardRenderer()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.render(frames[i%len(frames)])
+	}
+}
+
+// BenchmarkRenderBoardStill redraws the same tick, as when paused or when
+// frames outpace ticks at low game speeds
+func BenchmarkRenderBoardStill(b *testing.B) {
+	frames := benchFrames(b, 1)
+	r := newBoardRenderer()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.render(frames[0])
+	}
+}
@@ -0,0 +1,132 @@
+package engine
+
+// Kind is what a board cell shows
+type Kind uint8
+
+const (
+	KindEmpty Kind = iota
+	KindPlayer
+	KindMushroom
+	KindWing // A fly's flickering wing trail
+	KindFly
+	KindFlea
+	KindScorpion
+	KindSpider
+	KindSegment
+	KindExplosion
+	KindBullet
+)
+
+// Cell is one board cell: what is there and the state needed to draw it.
+// The engine says nothing about glyphs or colors; front ends map Kind and
+// state to whatever look they like.
+type Cell struct {
+	Kind      Kind
+	Health    int  // Mushroom hits left, 1-4
+	Poisoned  bool // Mushroom is poisoned
+	Head      bool // Segment is the head of its chain
+	Frame     int  // Explosion animation frame, 0 to MaxFrame-1
+	Trail     int  // Wing trail: cells behind the fly, 1 or 2
+	Direction int  // Heading of flies, spiders, scorpions and segments
+	Z         int  // Draw order; a cell only covers cells with lower or equal Z
+}
+
+// layers is the draw order of each kind. Heads sit one above bodies so a
+// head is never hidden behind another chain's body.
+var layers = [...]int{
+	KindPlayer:    1,
+	KindMushroom:  2,
+	KindWing:      3,
+	KindFly:       4,
+	KindFlea:      5,
+	KindScorpion:  6,
+	KindSpider:    7,
+	KindSegment:   8,
+	KindExplosion: 10,
+	KindBullet:    11,
+}
+
+// GetBoard returns the board as rows of cells, board[y][x], with only the
+// topmost thing in each cell
+func (g *Game) GetBoard() [][]Cell {
+	return g.DrawBoard(nil)
+}
+
+// DrawBoard is GetBoard drawing into board, so renderers can reuse one
+// board from frame to frame. A board of the wrong size is replaced; the
+// board drawn is returned either way.
+func (g *Game) DrawBoard(board [][]Cell) [][]Cell {
+	if len(board) != g.height || len(board) > 0 && len(board[0]) != g.width {
+		board = make([][]Cell, g.height)
+		for i := range board {
+			board[i] = make([]Cell, g.width)
+		}
+	} else {
+		for i := range board {
+			clear(board[i])
+		}
+	}
+	put := func(p Position, c Cell) {
+		if p.Y < 0 || p.Y >= g.height || p.X < 0 || p.X >= g.width {
+			return
+		}
+		c.Z = layers[c.Kind]
+		if c.Head {
+			c.Z++
+		}
+		if c.Z >= board[p.Y][p.X].Z {
+			board[p.Y][p.X] = c
+		}
+	}
+
+	// Player is hidden during respawn
+	if !g.respawning {
+		put(g.player.Pos, Cell{Kind: KindPlayer})
+	}
+	for _, mush := range g.mushrooms {
+		put(mush.Pos, Cell{Kind: KindMushroom, Health: mush.Health, Poisoned: mush.Poisoned})
+	}
+	for _, fly := range g.flies {
+		if !fly.Active {
+			continue
+		}
+		put(fly.Pos, Cell{Kind: KindFly, Direction: fly.Direction})
+		if fly.WingFlap {
+			for trail := 1; trail <= 2; trail++ {
+				p := Position{X: fly.Pos.X - fly.Direction*trail, Y: fly.Pos.Y}
+				put(p, Cell{Kind: KindWing, Trail: trail, Direction: fly.Direction})
+			}
+		}
+	}
+	for _, flea := range g.fleas {
+		if flea.Active {
+			put(flea.Pos, Cell{Kind: KindFlea})
+		}
+	}
+	for _, scorpion := range g.scorpions {
+		if scorpion.Active {
+			put(scorpion.Pos, Cell{Kind: KindScorpion, Direction: scorpion.Direction})
+		}
+	}
+	for _, spider := range g.spiders {
+		if spider.Active {
+			put(spider.Pos, Cell{Kind: KindSpider, Direction: spider.DX})
+		}
+	}
+	for _, c := range g.centipedes {
+		for si, seg := range c.Segments {
+			put(seg.Pos, Cell{Kind: KindSegment, Head: si == 0, Direction: seg.Direction})
+		}
+	}
+	for _, exp := range g.explosions {
+		if exp.Active {
+			put(exp.Pos, Cell{Kind: KindExplosion, Frame: exp.Frame})
+		}
+	}
+	for _, bullet := range g.bullets {
+		if bullet.Active {
+			put(bullet.Pos, Cell{Kind: KindBullet})
+		}
+	}
+	return board
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Clone returns an independent copy of the game, random state included, so
+// the copy plays out exactly as the original would for the same inputs.
+// Planners use it to try moves without touching the real game. The copy
+// records only the inputs applied to it, so its Replay is not the game's.
+func (g *Game) Clone() *Game {
+	c := *g
+	c.src = cloneSource(g.src)
+	c.rng = rand.New(c.src)
+
+	c.centipedes = make([]Centipede, len(g.centipedes))
+	for i, cent := range g.centipedes {
+		c.centipedes[i] = Centipede{Segments: append([]Segment(nil), cent.Segments...)}
+	}
+	c.bullets = append([]Bullet(nil), g.bullets...)
+	c.mushrooms = append([]Mushroom(nil), g.mushrooms...)
+	c.flies = append([]Fly(nil), g.flies...)
+	c.fleas = append([]Flea(nil), g.fleas...)
+	c.spiders = append([]Spider(nil), g.spiders...)
+	c.scorpions = append([]Scorpion(nil), g.scorpions...)
+	c.explosions = append([]Explosion(nil), g.explosions...)
+	c.grid = g.grid.clone()
+
+	// Copies are for planning, not replays: they start with no input
+	// history, so trying a move doesn't cost a copy of the whole game's
+	c.inputs = nil
+
+	// Planning copies must not fire the original's subscribers
+	c.events = append([]Event(nil), g.events...)
+	c.subscribers = nil
+	c.stats = g.Stats()
+	return &c
+}
+
+// cloneSource copies a math/rand source. The standard source has no API for
+// this, but it is a plain struct behind a pointer, so copying the struct
+// copies the generator state.
+func cloneSource(src rand.Source) rand.Source {
+	v := reflect.ValueOf(src)
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(rand.Source)
+}
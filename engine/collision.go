@@ -0,0 +1,212 @@
+package engine
+
+// Collisions are swept: every entity moves a cell at a time and checks what
+// it ran into after each cell, and a bullet counts as covering every cell it
+// flew through this tick. A bullet and a segment swapping cells, or a fly
+// crossing a mushroom mid-tick, can't pass through each other. The player
+// is checked on its own moves as well as the enemies', so walking into a
+// centipede or trading cells with one is as deadly as being run over.
+
+// shotAt reports whether a bullet covers p, spending the bullet if so
+func (g *Game) shotAt(p Position) bool {
+	b := g.bulletAt(p)
+	if b == nil {
+		return false
+	}
+	b.Active = false
+	return true
+}
+
+// resolveShot settles a bullet sitting at p against whatever is there:
+// centipedes first, then flies, fleas, spiders, scorpions and finally
+// mushrooms. Reports whether something was hit and the bullet is spent.
+// The grid says which kinds are in the cell, so empty cells cost no scans.
+func (g *Game) resolveShot(p Position) bool {
+	if g.grid.hasSegment(p) {
+		for ci := range g.centipedes {
+			for si, seg := range g.centipedes[ci].Segments {
+				if seg.Pos == p {
+					g.shootSegment(ci, si)
+					return true
+				}
+			}
+		}
+	}
+	if g.grid.hasEnemy(p) && g.shootEnemyAt(p) {
+		return true
+	}
+	if j := g.mushroomAt(p); j >= 0 {
+		g.shootMushroom(j)
+		return true
+	}
+	return false
+}
+
+// shootEnemyAt shoots the active fly, flea, spider or scorpion at p
+func (g *Game) shootEnemyAt(p Position) bool {
+	for j := range g.flies {
+		if g.flies[j].Active && g.flies[j].Pos == p {
+			g.shootFly(&g.flies[j])
+			return true
+		}
+	}
+	for j := range g.fleas {
+		if g.fleas[j].Active && g.fleas[j].Pos == p {
+			g.shootFlea(&g.fleas[j])
+			return true
+		}
+	}
+	for j := range g.spiders {
+		if g.spiders[j].Active && g.spiders[j].Pos == p {
+			g.shootSpider(&g.spiders[j])
+			return true
+		}
+	}
+	for j := range g.scorpions {
+		if g.scorpions[j].Active && g.scorpions[j].Pos == p {
+			g.shootScorpion(&g.scorpions[j])
+			return true
+		}
+	}
+	return false
+}
+
+// shootCentipedes kills every segment standing on a bullet's path. Run
+// after each centipede step, so segments can't step past a bullet.
+func (g *Game) shootCentipedes() {
+	for ci := 0; ci < len(g.centipedes); ci++ {
+		for si := range g.centipedes[ci].Segments {
+			if g.shotAt(g.centipedes[ci].Segments[si].Pos) {
+				// The chain now ends before si, and the part behind it was
+				// appended, so the outer loop still gets to it
+				g.shootSegment(ci, si)
+				break
+			}
+		}
+	}
+}
+
+// shootSegment scores segment si of centipede ci and removes it
+func (g *Game) shootSegment(ci, si int) {
+	seg := g.centipedes[ci].Segments[si]
+
+	// Create explosion
+	g.createExplosion(seg.Pos.X, seg.Pos.Y)
+
+	// Dead segment leaves a mushroom behind (classic rule)
+	g.addMushroom(seg.Pos.X, seg.Pos.Y)
+
+	// Extra points for head
+	points := 10
+	if si == 0 {
+		points = 100
+	}
+	g.score += points
+	g.emit(SegmentKilled{
+		Pos:    seg.Pos,
+		Head:   si == 0,
+		Chain:  len(g.centipedes[ci].Segments),
+		Points: points,
+	})
+
+	// Remove segment - splits the chain, the segment behind it becomes the
+	// head of a new centipede
+	g.killSegment(ci, si)
+}
+
+func (g *Game) shootFly(f *Fly) {
+	g.retire(&f.Active, f.Pos)
+	g.createExplosion(f.Pos.X, f.Pos.Y)
+	g.score += 200 // Flies worth 200 points
+	g.emit(FlyKilled{Pos: f.Pos, Points: 200})
+}
+
+func (g *Game) shootFlea(f *Flea) {
+	g.retire(&f.Active, f.Pos)
+	g.createExplosion(f.Pos.X, f.Pos.Y)
+	g.score += 150 // Fleas worth 150 points
+	g.emit(FleaKilled{Pos: f.Pos, Points: 150})
+}
+
+func (g *Game) shootSpider(s *Spider) {
+	g.retire(&s.Active, s.Pos)
+	g.createExplosion(s.Pos.X, s.Pos.Y)
+	points := g.spiderPoints(*s) // 300/600/900 by distance
+	g.score += points
+	g.emit(SpiderKilled{Pos: s.Pos, Points: points})
+}
+
+func (g *Game) shootScorpion(s *Scorpion) {
+	g.retire(&s.Active, s.Pos)
+	g.createExplosion(s.Pos.X, s.Pos.Y)
+	g.score += 1000 // Scorpions worth 1000 points
+	g.emit(ScorpionKilled{Pos: s.Pos, Points: 1000})
+}
+
+// shootMushroom chips mushroom j, removing it once its health runs out
+func (g *Game) shootMushroom(j int) {
+	g.mushrooms[j].Health--
+	hit := MushroomHit{Pos: g.mushrooms[j].Pos, Points: 1}
+	if g.mushrooms[j].Health <= 0 {
+		g.removeMushroom(j)
+		hit.Destroyed = true
+		hit.Points += 4
+	}
+	g.score += hit.Points
+	g.emit(hit)
+}
+
+// poisonMushroom poisons mushroom j unless it already is
+func (g *Game) poisonMushroom(j int, by PoisonSource) {
+	m := &g.mushrooms[j]
+	if m.Poisoned {
+		return
+	}
+	m.Poisoned = true
+	m.Scorpion = by == PoisonScorpion
+	g.emit(MushroomPoisoned{Pos: m.Pos, By: by})
+}
+
+// movePlayer puts the player on the free cell to and takes a life if a
+// segment, flea or spider is already there. Flies and scorpions are harmless
+// to touch, as when they move onto the player.
+func (g *Game) movePlayer(to Position) {
+	g.player.from, g.player.Pos = g.player.Pos, to
+	if g.respawning {
+		return // The player isn't on the board yet
+	}
+	if g.grid.hasSegment(to) {
+		for _, c := range g.centipedes {
+			for _, seg := range c.Segments {
+				if seg.Pos == to {
+					g.loseLife(LifeLost{Cause: CauseCentipede, Chute: seg.Poisoned, Scorpion: seg.Scorpion})
+					return
+				}
+			}
+		}
+	}
+	if !g.grid.hasEnemy(to) {
+		return
+	}
+	for j := range g.fleas {
+		if f := &g.fleas[j]; f.Active && f.Pos == to {
+			g.loseLife(LifeLost{Cause: CauseFlea})
+			g.retire(&f.Active, f.Pos)
+			return
+		}
+	}
+	for j := range g.spiders {
+		if s := &g.spiders[j]; s.Active && s.Pos == to {
+			g.loseLife(LifeLost{Cause: CauseSpider})
+			g.retire(&s.Active, s.Pos)
+			return
+		}
+	}
+}
+
+// swapsWithPlayer reports whether an enemy stepping from one cell to the
+// next trades places with the player, who moved the other way this tick
+func (g *Game) swapsWithPlayer(from, to Position) bool {
+	p := g.player
+	return p.from != p.Pos && from == p.Pos && to == p.from
+}
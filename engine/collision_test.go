@@ -0,0 +1,267 @@
+package engine
+
+import "testing"
+
+// quietGame is a board with no mushrooms and nothing spawning, so tests can
+// set collisions up by hand. The opening centipede stays in the top rows,
+// away from the cells the tests use, so the wave doesn't end under them.
+func quietGame(t *testing.T, tweak func(*Config)) *Game {
+	t.Helper()
+	c := DefaultConfig()
+	c.InitialMushrooms = 0
+	c.LevelMushrooms = 0
+	c.FlyChance = 0
+	c.FleaChance = 0
+	c.SpiderChance = 0
+	c.ScorpionChance = 0
+	c.ScorpionMaxChance = 0
+	if tweak != nil {
+		tweak(&c)
+	}
+	g := NewGameWithConfig(50, 28, 1, c)
+	g.mushrooms = nil
+	reindex(g)
+	return g
+}
+
+// reindex rebuilds the grid after a test has set the board up by hand
+func reindex(g *Game) {
+	g.grid = newGrid(g.width, g.height)
+	mushrooms := g.mushrooms
+	g.mushrooms = nil
+	for _, m := range mushrooms {
+		g.placeMushroom(m)
+	}
+	for _, c := range g.centipedes {
+		for _, seg := range c.Segments {
+			g.grid.addSegment(seg.Pos)
+		}
+	}
+	for i, b := range g.bullets {
+		g.grid.addBullet(i, b.Pos)
+	}
+	for _, f := range g.flies {
+		if f.Active {
+			g.grid.addEnemy(f.Pos)
+		}
+	}
+	for _, f := range g.fleas {
+		if f.Active {
+			g.grid.addEnemy(f.Pos)
+		}
+	}
+	for _, s := range g.spiders {
+		if s.Active {
+			g.grid.addEnemy(s.Pos)
+		}
+	}
+	for _, s := range g.scorpions {
+		if s.Active {
+			g.grid.addEnemy(s.Pos)
+		}
+	}
+}
+
+func addBullet(g *Game, x, y int) {
+	g.bullets = append(g.bullets, Bullet{Pos: Position{X: x, Y: y}, Active: true, fromY: y})
+}
+
+// A lone head dropping off the left edge moves down into the cell a bullet
+// is leaving. Checked only at their end positions the two swap cells and
+// never meet.
+func TestBulletSegmentSwap(t *testing.T) {
+	g := quietGame(t, nil)
+	g.centipedes = append(g.centipedes, Centipede{Segments: []Segment{
+		{Pos: Position{X: 1, Y: 19}, Direction: -1, Vertical: 1},
+	}})
+	addBullet(g, 0, 20)
+	reindex(g)
+
+	g.Step()
+
+	if got := g.Stats().HeadKills; got != 1 {
+		t.Fatalf("head kills = %d, want 1", got)
+	}
+	if len(g.bullets) != 0 {
+		t.Error("bullet still active after hitting the head")
+	}
+}
+
+// A head stepping sideways out of the cell a bullet moves into
+func TestBulletHitsSegmentMovingAway(t *testing.T) {
+	g := quietGame(t, nil)
+	g.centipedes = append(g.centipedes, Centipede{Segments: []Segment{
+		{Pos: Position{X: 10, Y: 19}, Direction: -1, Vertical: 1},
+	}})
+	addBullet(g, 10, 20)
+	reindex(g)
+
+	g.Step()
+
+	if got := g.Stats().HeadKills; got != 1 {
+		t.Fatalf("head kills = %d, want 1", got)
+	}
+}
+
+// A fly moving two cells a tick must not jump over a mushroom
+func TestFlyCannotSkipMushroom(t *testing.T) {
+	g := quietGame(t, nil)
+	g.mushrooms = []Mushroom{{Pos: Position{X: 11, Y: 8}, Health: 4}}
+	g.flies = []Fly{{Pos: Position{X: 10, Y: 8}, Direction: 1, Active: true}}
+	reindex(g)
+
+	g.Step()
+
+	if !g.mushrooms[0].Poisoned {
+		t.Error("mushroom the fly flew through was not poisoned")
+	}
+	if len(g.flies) != 0 {
+		t.Fatal("fly still active after hitting a mushroom")
+	}
+	if len(g.explosions) != 1 || g.explosions[0].Pos.X != 11 {
+		t.Errorf("fly should have burst at x=11, explosions = %v", g.explosions)
+	}
+}
+
+// A fly crossing the cell a bullet started the tick in still gets shot
+func TestFlyCrossingBulletPath(t *testing.T) {
+	g := quietGame(t, nil)
+	g.flies = []Fly{{Pos: Position{X: 8, Y: 12}, Direction: 1, Active: true}}
+	addBullet(g, 10, 12)
+	reindex(g)
+
+	g.Step()
+
+	if len(g.flies) != 0 {
+		t.Fatal("fly passed through the bullet's path")
+	}
+	if got := g.Stats().FlyKills; got != 1 {
+		t.Errorf("fly kills = %d, want 1", got)
+	}
+}
+
+// A bullet moving two cells a tick must not jump over a mushroom
+func TestFastBulletCannotSkipMushroom(t *testing.T) {
+	g := quietGame(t, func(c *Config) { c.BulletSpeed = 2 })
+	g.mushrooms = []Mushroom{{Pos: Position{X: 20, Y: 14}, Health: 4}}
+	addBullet(g, 20, 15)
+	reindex(g)
+
+	g.Step()
+
+	if got := g.mushrooms[0].Health; got != 3 {
+		t.Errorf("mushroom health = %d, want 3", got)
+	}
+	if len(g.bullets) != 0 {
+		t.Error("bullet flew on through the mushroom")
+	}
+}
+
+// A shot fired from right under a mushroom hits it instead of starting on
+// the far side
+func TestShotFromUnderMushroom(t *testing.T) {
+	g := quietGame(t, nil)
+	p := g.Player()
+	g.mushrooms = []Mushroom{{Pos: Position{X: p.X, Y: p.Y - 1}, Health: 4}}
+	reindex(g)
+
+	g.Apply(ActionShoot)
+	g.Step()
+
+	if got := g.mushrooms[0].Health; got != 3 {
+		t.Errorf("mushroom health = %d, want 3", got)
+	}
+}
+
+// A flea falling three cells a tick can't fall through the player
+func TestFastFleaHitsPlayer(t *testing.T) {
+	g := quietGame(t, func(c *Config) { c.FleaSpeed = 3 })
+	g.player.Pos = Position{X: 25, Y: 24}
+	g.fleas = []Flea{{Pos: Position{X: 25, Y: 22}, Active: true, speed: g.speeds.flea}}
+	reindex(g)
+
+	g.Step()
+
+	if got := g.Stats().DeathsByFlea; got != 1 {
+		t.Fatalf("flea deaths = %d, want 1", got)
+	}
+}
+
+// Each bullet hits one thing: a shot that kills a segment doesn't also
+// chip the mushroom the segment leaves behind
+func TestBulletHitsOneTarget(t *testing.T) {
+	g := quietGame(t, nil)
+	g.centipedes = append(g.centipedes, Centipede{Segments: []Segment{
+		{Pos: Position{X: 30, Y: 15}, Direction: 1, Vertical: 1},
+		{Pos: Position{X: 29, Y: 15}, Direction: 1, Vertical: 1},
+	}})
+	addBullet(g, 31, 16)
+	reindex(g)
+
+	g.Step()
+
+	s := g.Stats()
+	if s.SegmentKills() != 1 || s.ShotsHit != 1 {
+		t.Fatalf("segment kills = %d, shots hit = %d, want 1 and 1", s.SegmentKills(), s.ShotsHit)
+	}
+	i := g.mushroomAt(Position{X: 31, Y: 15})
+	if i < 0 || g.mushrooms[i].Health != 4 {
+		t.Error("dead segment should leave a full-health mushroom")
+	}
+}
+
+// The player steps right into the cell a lone head is leaving as the head
+// steps left into the player's. Checked only at their end positions the two
+// swap cells and never meet.
+func TestPlayerSegmentSwap(t *testing.T) {
+	g := quietGame(t, nil)
+	g.player.Pos = Position{X: 10, Y: 26}
+	g.centipedes = append(g.centipedes, Centipede{Segments: []Segment{
+		{Pos: Position{X: 11, Y: 26}, Direction: -1, Vertical: -1},
+	}})
+	reindex(g)
+
+	g.Apply(ActionRight)
+	g.Step()
+
+	if got := g.Stats().DeathsByCentipede; got != 1 {
+		t.Fatalf("centipede deaths = %d, want 1", got)
+	}
+}
+
+// The player steps up into a spider's cell as the spider drops into the
+// player's
+func TestPlayerSpiderSwap(t *testing.T) {
+	g := quietGame(t, nil)
+	g.player.Pos = Position{X: 10, Y: 25}
+	g.spiders = []Spider{{Pos: Position{X: 10, Y: 24}, DX: -1, DY: 1, Active: true}}
+	reindex(g)
+
+	g.Apply(ActionUp)
+	g.Step()
+
+	if got := g.Stats().DeathsBySpider; got != 1 {
+		t.Fatalf("spider deaths = %d, want 1", got)
+	}
+	if g.spiders[0].Active {
+		t.Error("spider still active after killing the player")
+	}
+}
+
+// The swap check on the enemy's side: a head stepping from the player's cell
+// into the one the player just left
+func TestHeadStepsIntoCellPlayerLeft(t *testing.T) {
+	g := quietGame(t, nil)
+	g.player = Player{Pos: Position{X: 11, Y: 26}, from: Position{X: 10, Y: 26}}
+
+	if !g.swapsWithPlayer(Position{X: 11, Y: 26}, Position{X: 10, Y: 26}) {
+		t.Error("head trading cells with the player not seen as a swap")
+	}
+	if g.swapsWithPlayer(Position{X: 12, Y: 26}, Position{X: 11, Y: 26}) {
+		t.Error("head stepping into the player counted as a swap")
+	}
+	g.player.from = g.player.Pos
+	if g.swapsWithPlayer(Position{X: 11, Y: 26}, Position{X: 10, Y: 26}) {
+		t.Error("swap seen when the player didn't move")
+	}
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Config holds the gameplay rules and tuning values that can be switched per
+// game. Start from DefaultConfig - the zero value is not a playable game.
+// Each field has a short name (its `config` tag) used by Set, by replay files
+// and by the balance simulator's overrides.
+type Config struct {
+	// EscapeKills restores the old rule where a centipede reaching the
+	// bottom row costs a life instead of roaming the player zone
+	EscapeKills bool `config:"escapeKills"`
+
+	StartingLives  int `config:"startingLives"`
+	BonusLifeScore int `config:"bonusLifeScore"` // A bonus life every this many points
+	RespawnTicks   int `config:"respawnTicks"`   // Pause after losing a life
+
+	InitialMushrooms int `config:"initialMushrooms"`
+	LevelMushrooms   int `config:"levelMushrooms"` // Added each level
+	PoisonDrop       int `config:"poisonDrop"`     // Rows a head falls when it hits a poison mushroom
+
+	FlyChance         float64 `config:"flyChance"`         // Per tick
+	FleaChance        float64 `config:"fleaChance"`        // Per tick, while mushrooms are scarce
+	FleaMushroomLimit int     `config:"fleaMushroomLimit"` // Fleas only come below this many mushrooms
+	SpiderChance      float64 `config:"spiderChance"`      // Per tick, one spider at a time
+	ScorpionChance    float64 `config:"scorpionChance"`    // Per tick, per level past 1
+	ScorpionMaxChance float64 `config:"scorpionMaxChance"` // Cap on the scorpion chance
+
+	// Speeds in cells per tick. Fractions carry over between ticks, so 0.5
+	// moves a cell every other tick.
+	BulletSpeed         float64 `config:"bulletSpeed"`
+	FlySpeed            float64 `config:"flySpeed"`
+	FleaSpeed           float64 `config:"fleaSpeed"`
+	FleaAccel           float64 `config:"fleaAccel"` // Added to a flea's speed every tick it falls
+	SpiderSpeed         float64 `config:"spiderSpeed"`
+	ScorpionSpeed       float64 `config:"scorpionSpeed"`
+	CentipedeSpeed      float64 `config:"centipedeSpeed"`      // On level 1
+	CentipedeSpeedLevel float64 `config:"centipedeSpeedLevel"` // Added for each level after 1
+	CentipedeMaxSpeed   float64 `config:"centipedeMaxSpeed"`   // Cap on the level curve
+}
+
+// DefaultConfig returns the standard arcade rules
+func DefaultConfig() Config {
+	return Config{
+		StartingLives:     3,
+		BonusLifeScore:    20000, // Was 10k, now 20k
+		RespawnTicks:      30,    // 30 ticks ~2.4 seconds
+		InitialMushrooms:  25,    // Was 15, now 25 for more obstacles
+		LevelMushrooms:    10,    // Was 5, now 10 per level
+		PoisonDrop:        3,     // Was 1, now 3 - TRUE CHUTE EFFECT!
+		FlyChance:         0.05,  // Was 0.02 (2%), now 0.05 (5%)
+		FleaChance:        0.03,
+		FleaMushroomLimit: 15,
+		SpiderChance:      0.01,
+		ScorpionChance:    0.002,
+		ScorpionMaxChance: 0.01,
+
+		BulletSpeed:         1,
+		FlySpeed:            2,
+		FleaSpeed:           1,
+		SpiderSpeed:         0.5,
+		ScorpionSpeed:       0.5,
+		CentipedeSpeed:      1,
+		CentipedeSpeedLevel: 0,
+		CentipedeMaxSpeed:   3,
+	}
+}
+
+// ConfigKeys lists the names accepted by Set, in declaration order
+func ConfigKeys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, t.NumField())
+	for i := range keys {
+		keys[i] = t.Field(i).Tag.Get("config")
+	}
+	return keys
+}
+
+// Set changes the field named key, parsing value for the field's type
+func (c *Config) Set(key, value string) error {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("config") != key {
+			continue
+		}
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("config %s: %v", key, err)
+			}
+			f.SetBool(b)
+		case reflect.Int:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("config %s: %v", key, err)
+			}
+			f.SetInt(int64(n))
+		case reflect.Float64:
+			x, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("config %s: %v", key, err)
+			}
+			f.SetFloat(x)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown config key %q", key)
+}
+
+// SetPair applies a single "key=value" override
+func (c *Config) SetPair(pair string) error {
+	key, value, ok := strings.Cut(pair, "=")
+	if !ok {
+		return fmt.Errorf("config override %q is not key=value", pair)
+	}
+	return c.Set(key, value)
+}
+
+// Pairs returns every field as "key=value", in declaration order
+func (c Config) Pairs() []string {
+	v := reflect.ValueOf(c)
+	t := v.Type()
+	pairs := make([]string, t.NumField())
+	for i := range pairs {
+		pairs[i] = fmt.Sprintf("%s=%v", t.Field(i).Tag.Get("config"), v.Field(i).Interface())
+	}
+	return pairs
+}
@@ -0,0 +1,114 @@
+// Package engine implements the Centipede game rules: entities, movement,
+// collisions, scoring and replays. It has no rendering or terminal code, so
+// the TUI, the balance simulator and other tools can all drive the same game.
+package engine
+
+// Game entity positions
+type Position struct {
+	X, Y int
+}
+
+// Player with improved gun character
+type Player struct {
+	Pos  Position
+	from Position // Cell it left this tick; Pos if it hasn't moved
+}
+
+// Bullet with improved rendering
+type Bullet struct {
+	Pos    Position
+	Active bool
+	fromY  int // Row it started the tick on; its path covers fromY up to Pos.Y
+	move   motion
+}
+
+// Centipede Segment
+type Segment struct {
+	Pos       Position
+	Direction int  // 1 = right, -1 = left
+	Vertical  int  // 1 = dropping down, -1 = climbing back up the player zone
+	Poisoned  bool // Its last drop was down a poison chute
+	Scorpion  bool // ...and a scorpion laid that poison
+}
+
+// Centipede is one independent chain of segments.
+// Segments[0] is the head (front of movement), the rest trail behind it.
+type Centipede struct {
+	Segments []Segment
+}
+
+// splitAt removes the segment at index i and returns the chains on either
+// side of it. The segment right behind the removed one becomes the head of
+// the rear chain. Either result may be empty.
+func (c Centipede) splitAt(i int) (front, rear Centipede) {
+	front.Segments = append([]Segment(nil), c.Segments[:i]...)
+	rear.Segments = append([]Segment(nil), c.Segments[i+1:]...)
+	return front, rear
+}
+
+// Mushroom obstacle
+type Mushroom struct {
+	Pos      Position
+	Health   int  // 0-4 hits to destroy
+	Poisoned bool // Poisoned mushrooms make centipede fall faster
+	Scorpion bool // Poison came from a scorpion rather than a fly
+}
+
+// Fly enemy
+type Fly struct {
+	Pos       Position
+	Direction int // 1 = right, -1 = left
+	Active    bool
+	WingFlap  bool // Alternates for wing animation
+	move      motion
+}
+
+// Flea enemy - falls from top and creates mushrooms
+type Flea struct {
+	Pos    Position
+	Active bool
+	speed  fixed // Grows by fleaAccel every tick it falls
+	move   motion
+}
+
+// Spider enemy - bounces erratically through the player zone eating mushrooms
+type Spider struct {
+	Pos    Position
+	DX     int // 1 = right, -1 = left
+	DY     int // 1 = down, -1 = up
+	Active bool
+	move   motion
+}
+
+// Scorpion enemy - crosses the upper field poisoning every mushroom it touches
+type Scorpion struct {
+	Pos       Position
+	Direction int // 1 = right, -1 = left
+	Active    bool
+	move      motion
+}
+
+// Explosion effect
+type Explosion struct {
+	Pos      Position
+	Frame    int
+	MaxFrame int
+	Active   bool
+}
+
+func (e *Explosion) update() {
+	if !e.Active {
+		return
+	}
+	e.Frame++
+	if e.Frame >= e.MaxFrame {
+		e.Active = false
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
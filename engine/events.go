@@ -0,0 +1,155 @@
+package engine
+
+// Event is something that happened during a Step. Consumers type-switch on
+// the concrete types below. Every Step starts a fresh batch: read it with
+// Events after the Step, or Subscribe to get each event as it happens.
+type Event interface {
+	event()
+}
+
+// DeathCause says what took a life
+type DeathCause int
+
+const (
+	CauseCentipede DeathCause = iota // A segment ran into the player
+	CauseEscape                      // A head reached the bottom (EscapeKills only)
+	CauseFlea
+	CauseSpider
+)
+
+func (c DeathCause) String() string {
+	switch c {
+	case CauseCentipede:
+		return "centipede"
+	case CauseEscape:
+		return "escape"
+	case CauseFlea:
+		return "flea"
+	case CauseSpider:
+		return "spider"
+	}
+	return "unknown"
+}
+
+// PoisonSource says what poisoned a mushroom
+type PoisonSource int
+
+const (
+	PoisonFly PoisonSource = iota
+	PoisonScorpion
+)
+
+func (p PoisonSource) String() string {
+	if p == PoisonScorpion {
+		return "scorpion"
+	}
+	return "fly"
+}
+
+// SegmentKilled is a centipede segment shot by the player. Chain is the
+// length of the centipede it belonged to before the hit.
+type SegmentKilled struct {
+	Pos    Position
+	Head   bool
+	Chain  int
+	Points int
+}
+
+// FlyKilled, FleaKilled, SpiderKilled and ScorpionKilled are enemies shot
+// by the player
+type FlyKilled struct {
+	Pos    Position
+	Points int
+}
+
+type FleaKilled struct {
+	Pos    Position
+	Points int
+}
+
+type SpiderKilled struct {
+	Pos    Position
+	Points int // 300/600/900 depending on how close the spider was
+}
+
+type ScorpionKilled struct {
+	Pos    Position
+	Points int
+}
+
+// MushroomHit is a bullet chipping a mushroom. Destroyed is set when the
+// hit took its last point of health.
+type MushroomHit struct {
+	Pos       Position
+	Destroyed bool
+	Points    int
+}
+
+// MushroomPoisoned is a healthy mushroom turned poisonous
+type MushroomPoisoned struct {
+	Pos Position
+	By  PoisonSource
+}
+
+// LifeLost is the player dying. Lives is what is left afterwards. Chute is
+// set when the killer's last drop was down a poison chute, and Scorpion when
+// a scorpion laid that poison.
+type LifeLost struct {
+	Cause    DeathCause
+	Chute    bool
+	Scorpion bool
+	Pos      Position
+	Lives    int
+}
+
+// GameOver follows the LifeLost that used up the last life
+type GameOver struct {
+	Score int
+	Level int
+}
+
+// LevelCleared is the last segment of a wave going down. Level is the level
+// just started and Ticks how long the cleared one took.
+type LevelCleared struct {
+	Level int
+	Ticks int
+}
+
+// BonusLife is an extra life earned for score
+type BonusLife struct {
+	Score int
+	Lives int
+}
+
+func (SegmentKilled) event()    {}
+func (FlyKilled) event()        {}
+func (FleaKilled) event()       {}
+func (SpiderKilled) event()     {}
+func (ScorpionKilled) event()   {}
+func (MushroomHit) event()      {}
+func (MushroomPoisoned) event() {}
+func (LifeLost) event()         {}
+func (GameOver) event()         {}
+func (LevelCleared) event()     {}
+func (BonusLife) event()        {}
+
+// Events returns what happened during the latest Step. The slice is reused
+// by the next Step, so copy it to keep it.
+func (g *Game) Events() []Event {
+	return g.events
+}
+
+// Subscribe registers fn to be called with every event as it is emitted.
+// Subscribers are not carried over by Clone.
+func (g *Game) Subscribe(fn func(Event)) {
+	g.subscribers = append(g.subscribers, fn)
+}
+
+// emit records an event for this tick and hands it to subscribers
+func (g *Game) emit(e Event) {
+	g.stats.record(e)
+	g.events = append(g.events, e)
+	for _, fn := range g.subscribers {
+		fn(e)
+	}
+}
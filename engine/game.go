@@ -0,0 +1,889 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// TickDuration is one Step of game time at normal speed. The engine never
+// reads the clock itself; front ends pace Step with it and use it to turn
+// tick counts into seconds.
+const TickDuration = 50 * time.Millisecond
+
+// Board sizes. The rules were tuned on the default board; other sizes scale
+// spawn positions, zone depths and mushroom counts from it. Smaller than the
+// minimum leaves no room for the spawn rules, and past the maximum the
+// scaled rules stop playing like Centipede.
+const (
+	DefaultWidth  = 50
+	DefaultHeight = 28
+	MinWidth      = 30
+	MinHeight     = 20
+	MaxWidth      = 100
+	MaxHeight     = 40
+)
+
+// CheckSize returns an error if a width x height board is outside the
+// minimum and maximum sizes. NewGame assumes the board it is given passes;
+// callers taking a size from players, files or other programs check it
+// first.
+func CheckSize(width, height int) error {
+	if width < MinWidth || height < MinHeight || width > MaxWidth || height > MaxHeight {
+		return fmt.Errorf("board must be from %dx%d to %dx%d, got %dx%d",
+			MinWidth, MinHeight, MaxWidth, MaxHeight, width, height)
+	}
+	return nil
+}
+
+// Game state
+type Game struct {
+	config        Config
+	speeds        speedTable  // config's speeds in fixed point
+	seed          int64       // Seed the game was created with
+	rng           *rand.Rand  // All engine randomness flows through here
+	src           rand.Source // rng's source, kept so Clone can copy its state
+	width         int
+	height        int
+	player        Player
+	centipedes    []Centipede
+	bullets       []Bullet
+	mushrooms     []Mushroom
+	flies         []Fly
+	fleas         []Flea
+	spiders       []Spider
+	scorpions     []Scorpion
+	explosions    []Explosion
+	score         int
+	level         int
+	lives         int
+	lastLifeScore int // Track score for bonus life awards
+	respawning    bool
+	respawnTimer  int
+	gameOver      bool
+	won           bool
+	tick          int     // Number of game ticks simulated so far
+	inputs        []Input // Every input applied, for replays
+	events        []Event // What happened during the latest Step
+	subscribers   []func(Event)
+	stats         Stats  // Per-game telemetry
+	levelStart    int    // Tick the current level started on
+	centipedeMove motion // Shared by every chain, they all move in step
+	grid          grid   // What occupies each cell, see grid.go
+
+	// Lone head pressure: once a centipede reaches the player zone, single
+	// heads start entering from the sides until the wave is cleared
+	zoneEntered  bool // A segment has reached the player zone this wave
+	headTimer    int  // Ticks until the next lone head enters
+	headInterval int  // Current gap between lone heads, shrinks each spawn
+}
+
+// Lone head timing, in ticks
+const (
+	loneHeadFirstInterval = 120
+	loneHeadMinInterval   = 30
+	loneHeadSpeedup       = 15
+)
+
+// NewGame creates a game with the default rules. The same seed always
+// produces the same game for the same inputs. The board must pass
+// CheckSize.
+func NewGame(width, height int, seed int64) *Game {
+	return NewGameWithConfig(width, height, seed, DefaultConfig())
+}
+
+func NewGameWithConfig(width, height int, seed int64, config Config) *Game {
+	src := rand.NewSource(seed)
+	g := &Game{
+		config:        config,
+		speeds:        newSpeedTable(config),
+		seed:          seed,
+		rng:           rand.New(src),
+		src:           src,
+		width:         width,
+		height:        height,
+		player:        Player{Pos: Position{X: width / 2, Y: height - 2}, from: Position{X: width / 2, Y: height - 2}},
+		level:         1,
+		lives:         config.StartingLives,
+		lastLifeScore: 0,
+		grid:          newGrid(width, height),
+	}
+
+	// Create initial centipede at top with head
+	g.spawnCentipede(10)
+
+	// Spawn SECOND centipede for increased difficulty!
+	g.spawnSecondCentipede(8)
+
+	// Create random mushrooms - INCREASED for difficulty
+	g.spawnMushrooms(g.config.InitialMushrooms)
+
+	return g
+}
+
+func (g *Game) spawnSecondCentipede(length int) {
+	// Spawn second centipede offset from first
+	startX := g.scaleX(25) // Offset from first centipede
+	startY := 2
+
+	// Moving left (opposite of first), so the head is the leftmost segment
+	c := Centipede{}
+	for i := 0; i < length; i++ {
+		c.Segments = append(c.Segments, Segment{
+			Pos:       Position{X: startX + i, Y: startY},
+			Direction: -1,
+			Vertical:  1,
+		})
+		g.grid.addSegment(c.Segments[i].Pos)
+	}
+	g.centipedes = append(g.centipedes, c)
+}
+
+func (g *Game) spawnCentipede(length int) {
+	startX := g.scaleX(5)
+	startY := 2
+
+	// Moving right, so the head is the rightmost segment
+	c := Centipede{}
+	for i := 0; i < length; i++ {
+		c.Segments = append(c.Segments, Segment{
+			Pos:       Position{X: startX + length - 1 - i, Y: startY},
+			Direction: 1,
+			Vertical:  1,
+		})
+		g.grid.addSegment(c.Segments[i].Pos)
+	}
+	g.centipedes = append(g.centipedes, c)
+}
+
+// spawnLoneHead sends a single-segment centipede in from the left or right
+// edge at player-zone height
+func (g *Game) spawnLoneHead() {
+	top := g.PlayerZoneTop()
+	y := top + g.rng.Intn(g.PlayerZoneBottom()-top+1)
+	direction := 1
+	startX := 0
+	if g.rng.Float64() < 0.5 {
+		direction = -1
+		startX = g.width - 1
+	}
+	g.centipedes = append(g.centipedes, Centipede{
+		Segments: []Segment{{
+			Pos:       Position{X: startX, Y: y},
+			Direction: direction,
+			Vertical:  1,
+		}},
+	})
+	g.grid.addSegment(Position{X: startX, Y: y})
+	g.stats.LoneHeadsSpawned++
+}
+
+// updateLoneHeads runs the wave timer that feeds lone heads into the player
+// zone, spawning them faster the longer the wave lingers
+func (g *Game) updateLoneHeads() {
+	// Escaping centipedes already cost a life under the old rule
+	if g.config.EscapeKills {
+		return
+	}
+
+	if !g.zoneEntered {
+		for _, c := range g.centipedes {
+			for _, seg := range c.Segments {
+				if seg.Pos.Y >= g.PlayerZoneTop() {
+					g.zoneEntered = true
+				}
+			}
+		}
+		if !g.zoneEntered {
+			return
+		}
+		g.headInterval = loneHeadFirstInterval
+		g.headTimer = g.headInterval
+	}
+
+	g.headTimer--
+	if g.headTimer <= 0 {
+		g.spawnLoneHead()
+		g.headInterval -= loneHeadSpeedup
+		if g.headInterval < loneHeadMinInterval {
+			g.headInterval = loneHeadMinInterval
+		}
+		g.headTimer = g.headInterval
+	}
+}
+
+// killSegment removes segment si of centipede ci, splitting the chain in two.
+// The front part keeps its slot and the rear part is appended as a new
+// centipede. Empty chains are left in place until pruneCentipedes runs.
+func (g *Game) killSegment(ci, si int) {
+	g.grid.removeSegment(g.centipedes[ci].Segments[si].Pos)
+	front, rear := g.centipedes[ci].splitAt(si)
+	g.centipedes[ci] = front
+	if len(rear.Segments) > 0 {
+		g.centipedes = append(g.centipedes, rear)
+	}
+}
+
+// pruneCentipedes drops chains that have no segments left
+func (g *Game) pruneCentipedes() {
+	alive := g.centipedes[:0]
+	for _, c := range g.centipedes {
+		if len(c.Segments) > 0 {
+			alive = append(alive, c)
+		}
+	}
+	g.centipedes = alive
+}
+
+// SegmentCount returns the number of segments across all centipedes
+func (g *Game) SegmentCount() int {
+	count := 0
+	for _, c := range g.centipedes {
+		count += len(c.Segments)
+	}
+	return count
+}
+
+// spawnMushrooms scatters count mushrooms, or as many as keeps the default
+// board's density on other sizes
+func (g *Game) spawnMushrooms(count int) {
+	count = g.scaleCount(count)
+	for i := 0; i < count; i++ {
+		x := g.rng.Intn(g.width-2) + 1
+		y := g.rng.Intn(g.height-5) + 2 // Avoid player area
+		g.placeMushroom(Mushroom{
+			Pos:    Position{X: x, Y: y},
+			Health: 4,
+		})
+	}
+}
+
+func (g *Game) spawnFly() {
+	// Random chance to spawn fly - INCREASED for difficulty
+	if g.rng.Float64() < g.config.FlyChance {
+		y := g.rng.Intn(g.height-g.scaleY(10)) + 3 // Middle area
+		direction := 1
+		startX := 0
+		if g.rng.Float64() < 0.5 {
+			direction = -1
+			startX = g.width - 1
+		}
+
+		g.flies = append(g.flies, Fly{
+			Pos:       Position{X: startX, Y: y},
+			Direction: direction,
+			Active:    true,
+			WingFlap:  false,
+		})
+		g.grid.addEnemy(Position{X: startX, Y: y})
+	}
+}
+
+func (g *Game) spawnFlea() {
+	// Spawn falling fleas when mushroom count is low
+	mushroomCount := len(g.mushrooms)
+	if mushroomCount < g.scaleCount(g.config.FleaMushroomLimit) && g.rng.Float64() < g.config.FleaChance {
+		x := g.rng.Intn(g.width-4) + 2
+		g.fleas = append(g.fleas, Flea{
+			Pos:    Position{X: x, Y: 2},
+			Active: true,
+			speed:  g.speeds.flea,
+		})
+		g.grid.addEnemy(Position{X: x, Y: 2})
+	}
+}
+
+func (b *Bullet) update(g *Game) {
+	if !b.Active {
+		return
+	}
+	b.fromY = b.Pos.Y
+
+	// A fresh shot, or anything that spawned onto the bullet, is hit where
+	// it stands before the bullet moves on
+	if g.resolveShot(b.Pos) {
+		b.Active = false
+		return
+	}
+	for n := b.move.advance(g.speeds.bullet); n > 0; n-- {
+		b.Pos.Y--
+		if b.Pos.Y < 0 {
+			b.Active = false
+			return
+		}
+		if g.resolveShot(b.Pos) {
+			b.Active = false
+			return
+		}
+	}
+}
+
+func (f *Fly) update(g *Game) {
+	if !f.Active {
+		return
+	}
+
+	// Toggle wing flap
+	f.WingFlap = !f.WingFlap
+
+	// Move horizontally, a cell at a time
+	for n := f.move.advance(g.speeds.fly); n > 0; n-- {
+		g.moveEnemy(&f.Pos, Position{X: f.Pos.X + f.Direction, Y: f.Pos.Y})
+
+		// Deactivate if off screen
+		if f.Pos.X < 0 || f.Pos.X >= g.width {
+			g.retire(&f.Active, f.Pos)
+			return
+		}
+
+		if g.shotAt(f.Pos) {
+			g.shootFly(f)
+			return
+		}
+
+		// Fly hits mushroom - make it poisoned!
+		if j := g.mushroomAt(f.Pos); j >= 0 {
+			g.poisonMushroom(j, PoisonFly)
+			g.retire(&f.Active, f.Pos)
+			g.createExplosion(f.Pos.X, f.Pos.Y)
+			return
+		}
+	}
+}
+
+func (f *Flea) update(g *Game) {
+	if !f.Active {
+		return
+	}
+
+	// Flea falls straight down, speeding up as it goes
+	n := f.move.advance(f.speed)
+	f.speed += g.speeds.fleaAccel
+	for ; n > 0; n-- {
+		g.moveEnemy(&f.Pos, Position{X: f.Pos.X, Y: f.Pos.Y + 1})
+
+		if g.shotAt(f.Pos) {
+			g.shootFlea(f)
+			return
+		}
+		if f.Pos == g.player.Pos {
+			g.loseLife(LifeLost{Cause: CauseFlea})
+			g.retire(&f.Active, f.Pos)
+			return
+		}
+
+		// Create mushroom occasionally as it falls
+		if g.rng.Float64() < 0.4 && f.Pos.Y > 5 { // 40% chance per cell
+			// Add mushroom at current position if none exists
+			g.addMushroom(f.Pos.X, f.Pos.Y)
+		}
+
+		// Deactivate if reached bottom
+		if f.Pos.Y >= g.height-2 {
+			g.retire(&f.Active, f.Pos)
+			return
+		}
+	}
+}
+
+// addMushroom places a fresh full-health mushroom at (x, y) unless one is
+// already there. An existing mushroom is left untouched so poisoned
+// mushrooms stay poisoned. Reports whether a mushroom was added.
+func (g *Game) addMushroom(x, y int) bool {
+	if !g.placeMushroom(Mushroom{Pos: Position{X: x, Y: y}, Health: 4}) {
+		return false
+	}
+	g.stats.MushroomsCreated++
+	return true
+}
+
+// removeMushroomAt deletes the mushroom at (x, y), if any.
+// Reports whether a mushroom was removed.
+func (g *Game) removeMushroomAt(x, y int) bool {
+	i := g.mushroomAt(Position{X: x, Y: y})
+	if i < 0 {
+		return false
+	}
+	g.removeMushroom(i)
+	return true
+}
+
+// scaleX and scaleY map a distance on the default board to this board's
+// size, rounding to the nearest cell
+func (g *Game) scaleX(x int) int {
+	return (x*g.width + DefaultWidth/2) / DefaultWidth
+}
+
+func (g *Game) scaleY(y int) int {
+	return (y*g.height + DefaultHeight/2) / DefaultHeight
+}
+
+// scaleCount scales a count of things on the default board by board area
+func (g *Game) scaleCount(n int) int {
+	area := DefaultWidth * DefaultHeight
+	return (n*g.width*g.height + area/2) / area
+}
+
+// PlayerZoneTop and PlayerZoneBottom bound the rows the player can move in
+func (g *Game) PlayerZoneTop() int {
+	return g.height - g.scaleY(6)
+}
+
+func (g *Game) PlayerZoneBottom() int {
+	return g.height - 2
+}
+
+func (g *Game) spawnSpider() {
+	// Only one spider at a time
+	for _, s := range g.spiders {
+		if s.Active {
+			return
+		}
+	}
+	if g.rng.Float64() < g.config.SpiderChance {
+		top := g.PlayerZoneTop()
+		y := top + g.rng.Intn(g.PlayerZoneBottom()-top+1)
+		dx := 1
+		startX := 0
+		if g.rng.Float64() < 0.5 {
+			dx = -1
+			startX = g.width - 1
+		}
+		dy := 1
+		if g.rng.Float64() < 0.5 {
+			dy = -1
+		}
+		g.spiders = append(g.spiders, Spider{
+			Pos:    Position{X: startX, Y: y},
+			DX:     dx,
+			DY:     dy,
+			Active: true,
+		})
+		g.grid.addEnemy(Position{X: startX, Y: y})
+	}
+}
+
+func (s *Spider) update(g *Game) {
+	if !s.Active {
+		return
+	}
+
+	for n := s.move.advance(g.speeds.spider); n > 0; n-- {
+		// Erratic bounce: sometimes hop straight up/down, sometimes flip vertically
+		if g.rng.Float64() < 0.15 {
+			s.DY *= -1
+		}
+		to := s.Pos
+		if g.rng.Float64() >= 0.3 {
+			to.X += s.DX
+		}
+		to.Y += s.DY
+		from := s.Pos
+
+		// Bounce off the top and bottom of the player zone
+		if to.Y <= g.PlayerZoneTop() {
+			to.Y = g.PlayerZoneTop()
+			s.DY = 1
+		} else if to.Y >= g.PlayerZoneBottom() {
+			to.Y = g.PlayerZoneBottom()
+			s.DY = -1
+		}
+		g.moveEnemy(&s.Pos, to)
+
+		// Deactivate if off screen
+		if s.Pos.X < 0 || s.Pos.X >= g.width {
+			g.retire(&s.Active, s.Pos)
+			return
+		}
+
+		if g.shotAt(s.Pos) {
+			g.shootSpider(s)
+			return
+		}
+		if s.Pos == g.player.Pos || g.swapsWithPlayer(from, s.Pos) {
+			g.loseLife(LifeLost{Cause: CauseSpider})
+			g.retire(&s.Active, s.Pos)
+			return
+		}
+
+		// Spiders eat any mushroom they pass over
+		if g.removeMushroomAt(s.Pos.X, s.Pos.Y) {
+			g.stats.MushroomsEaten++
+		}
+	}
+}
+
+// spiderPoints scores a spider by how close it was to the player when shot
+func (g *Game) spiderPoints(s Spider) int {
+	dist := abs(s.Pos.X - g.player.Pos.X)
+	if dy := abs(s.Pos.Y - g.player.Pos.Y); dy > dist {
+		dist = dy
+	}
+	switch {
+	case dist <= 2:
+		return 900
+	case dist <= 5:
+		return 600
+	default:
+		return 300
+	}
+}
+
+// scorpionChance is the per-tick scorpion spawn chance for the current level.
+// Scorpions start at level 2 and get more frequent every level after.
+func (g *Game) scorpionChance() float64 {
+	if g.level < 2 {
+		return 0
+	}
+	chance := g.config.ScorpionChance * float64(g.level-1)
+	if chance > g.config.ScorpionMaxChance {
+		chance = g.config.ScorpionMaxChance
+	}
+	return chance
+}
+
+func (g *Game) spawnScorpion() {
+	// Only one scorpion at a time
+	for _, s := range g.scorpions {
+		if s.Active {
+			return
+		}
+	}
+	if g.rng.Float64() < g.scorpionChance() {
+		y := g.rng.Intn(g.height/2-2) + 2 // Upper field only
+		direction := 1
+		startX := 0
+		if g.rng.Float64() < 0.5 {
+			direction = -1
+			startX = g.width - 1
+		}
+		g.scorpions = append(g.scorpions, Scorpion{
+			Pos:       Position{X: startX, Y: y},
+			Direction: direction,
+			Active:    true,
+		})
+		g.grid.addEnemy(Position{X: startX, Y: y})
+	}
+}
+
+func (s *Scorpion) update(g *Game) {
+	if !s.Active {
+		return
+	}
+
+	for n := s.move.advance(g.speeds.scorpion); n > 0; n-- {
+		g.moveEnemy(&s.Pos, Position{X: s.Pos.X + s.Direction, Y: s.Pos.Y})
+
+		// Deactivate if off screen
+		if s.Pos.X < 0 || s.Pos.X >= g.width {
+			g.retire(&s.Active, s.Pos)
+			return
+		}
+
+		if g.shotAt(s.Pos) {
+			g.shootScorpion(s)
+			return
+		}
+
+		// Poison whatever mushroom it walks over and keep going
+		if j := g.mushroomAt(s.Pos); j >= 0 {
+			g.poisonMushroom(j, PoisonScorpion)
+		}
+	}
+}
+
+func (g *Game) createExplosion(x, y int) {
+	g.explosions = append(g.explosions, Explosion{
+		Pos:      Position{X: x, Y: y},
+		Frame:    0,
+		MaxFrame: 4,
+		Active:   true,
+	})
+}
+
+// Step advances the game by one tick
+func (g *Game) Step() {
+	if g.gameOver || g.won {
+		return
+	}
+	g.tick++
+	g.events = g.events[:0]
+
+	// Handle respawn timer
+	if g.respawning {
+		g.respawnTimer--
+		if g.respawnTimer <= 0 {
+			g.respawning = false
+			// Clear any segments near player area
+			for ci := 0; ci < len(g.centipedes); ci++ {
+				for si := len(g.centipedes[ci].Segments) - 1; si >= 0; si-- {
+					if g.centipedes[ci].Segments[si].Pos.Y >= g.height-g.scaleY(10) {
+						g.killSegment(ci, si)
+					}
+				}
+			}
+			g.pruneCentipedes()
+		}
+		g.player.from = g.player.Pos
+		return // Don't update game during respawn
+	}
+
+	// Check for bonus life every BonusLifeScore points (20,000 by default)
+	if g.config.BonusLifeScore > 0 && g.score >= g.lastLifeScore+g.config.BonusLifeScore {
+		g.lives++
+		g.lastLifeScore = g.score - (g.score % g.config.BonusLifeScore) // Set to nearest step
+		g.emit(BonusLife{Score: g.score, Lives: g.lives})
+	}
+
+	// Move everything a cell at a time, resolving collisions after each
+	// cell (see collision.go). Bullets go first so their paths for this tick
+	// are known when the others move.
+	for i := range g.bullets {
+		g.bullets[i].update(g)
+	}
+	for i := range g.flies {
+		g.flies[i].update(g)
+	}
+	for i := range g.fleas {
+		g.fleas[i].update(g)
+	}
+	for i := range g.spiders {
+		g.spiders[i].update(g)
+	}
+	for i := range g.scorpions {
+		g.scorpions[i].update(g)
+	}
+
+	// Update explosions
+	for i := range g.explosions {
+		g.explosions[i].update()
+	}
+
+	// Spawn flies, fleas, spiders and scorpions
+	g.spawnFly()
+	g.spawnFlea()
+	g.spawnSpider()
+	g.spawnScorpion()
+
+	// Update centipedes - heads steer, bodies follow. Faster levels take
+	// several single-cell steps a tick, and bullets are checked after each.
+	// Bullets may already have emptied chains this tick, so prune first.
+	g.pruneCentipedes()
+	for n := g.centipedeMove.advance(g.speeds.centipedeSpeed(g.level)); n > 0; n-- {
+		for ci := 0; ci < len(g.centipedes); ci++ {
+			g.moveCentipede(ci)
+		}
+		g.shootCentipedes()
+		g.pruneCentipedes()
+	}
+	g.updateLoneHeads()
+
+	// Check win condition - spawn longer centipede instead of stopping
+	if len(g.centipedes) == 0 {
+		g.level++
+		g.emit(LevelCleared{Level: g.level, Ticks: g.tick - g.levelStart})
+		g.levelStart = g.tick
+		// New wave - lone heads stop until a centipede reaches the zone again
+		g.zoneEntered = false
+		// Spawn centipede with more segments each level (10 + level*2)
+		g.spawnCentipede(10 + g.level*2)
+		// Add more mushrooms too - DOUBLED for difficulty
+		g.spawnMushrooms(g.config.LevelMushrooms)
+		// Regenerate all mushrooms to full health
+		g.regenerateMushrooms()
+	}
+
+	g.player.from = g.player.Pos
+	g.compactEntities()
+}
+
+// compactEntities drops everything that went inactive this tick. Removal is
+// in place, so the slices keep their capacity and later spawns reuse the
+// freed slots: storage stays as big as the busiest moment of the game
+// rather than growing with every shot fired.
+func (g *Game) compactEntities() {
+	g.bullets = slices.DeleteFunc(g.bullets, func(b Bullet) bool { return !b.Active })
+	g.flies = slices.DeleteFunc(g.flies, func(f Fly) bool { return !f.Active })
+	g.fleas = slices.DeleteFunc(g.fleas, func(f Flea) bool { return !f.Active })
+	g.spiders = slices.DeleteFunc(g.spiders, func(s Spider) bool { return !s.Active })
+	g.scorpions = slices.DeleteFunc(g.scorpions, func(s Scorpion) bool { return !s.Active })
+	g.explosions = slices.DeleteFunc(g.explosions, func(e Explosion) bool { return !e.Active })
+
+	// Surviving bullets have moved down the slice
+	g.grid.clearBullets()
+	for i, b := range g.bullets {
+		g.grid.addBullet(i, b.Pos)
+	}
+}
+
+// moveCentipede advances centipede ci one step, follow-the-leader style.
+// Every body segment steps into the cell the segment ahead of it just left,
+// so the chain keeps its shape; only the head reacts to edges and mushrooms.
+func (g *Game) moveCentipede(ci int) {
+	c := &g.centipedes[ci]
+	g.grid.removeSegment(c.Segments[len(c.Segments)-1].Pos)
+	for i := len(c.Segments) - 1; i > 0; i-- {
+		c.Segments[i] = c.Segments[i-1]
+	}
+	from := c.Segments[0].Pos
+	g.moveHead(&c.Segments[0])
+	g.grid.addSegment(c.Segments[0].Pos)
+
+	// Check for collision with player, including a head and the player
+	// trading cells
+	for si, seg := range c.Segments {
+		if seg.Pos == g.player.Pos || si == 0 && g.swapsWithPlayer(from, seg.Pos) {
+			g.loseLife(LifeLost{Cause: CauseCentipede, Chute: seg.Poisoned, Scorpion: seg.Scorpion})
+			break
+		}
+	}
+
+	// With EscapeKills, a head reaching the bottom without hitting the player
+	// is a death. Otherwise dropHead turns it back up into the player zone.
+	if g.config.EscapeKills && c.Segments[0].Pos.Y >= g.PlayerZoneBottom() {
+		head := c.Segments[0]
+		g.loseLife(LifeLost{Cause: CauseEscape, Chute: head.Poisoned, Scorpion: head.Scorpion})
+		// Remove the head so we don't trigger multiple deaths from same segment
+		g.killSegment(ci, 0)
+	}
+}
+
+// dropHead moves a head the given number of rows in its vertical direction.
+// A head that runs out of room at the bottom turns around and climbs back
+// up the player zone, and turns down again once it reaches the zone's top,
+// so centipedes that get through keep hunting the player.
+func (g *Game) dropHead(seg *Segment, rows int) {
+	top, bottom := g.PlayerZoneTop(), g.PlayerZoneBottom()
+	seg.Pos.Y += seg.Vertical * rows
+
+	if g.config.EscapeKills {
+		return // Reaching the bottom is handled as an escape
+	}
+
+	if seg.Vertical > 0 && seg.Pos.Y > bottom {
+		seg.Pos.Y = bottom - (seg.Pos.Y - bottom)
+		seg.Vertical = -1
+	} else if seg.Vertical < 0 && seg.Pos.Y < top {
+		seg.Pos.Y = top + (top - seg.Pos.Y)
+		seg.Vertical = 1
+	}
+}
+
+// moveHead makes the turn decisions for a centipede head
+func (g *Game) moveHead(seg *Segment) {
+	seg.Pos.X += seg.Direction
+
+	// Hit edge - drop down and reverse
+	if seg.Pos.X <= 0 || seg.Pos.X >= g.width-1 {
+		g.dropHead(seg, 1)
+		seg.Direction *= -1
+		seg.Poisoned, seg.Scorpion = false, false
+	}
+
+	// Check if hit mushroom - drop down and reverse
+	hitPoisonMushroom := false
+	if j := g.mushroomAt(seg.Pos); j >= 0 {
+		mush := g.mushrooms[j]
+		if mush.Poisoned {
+			// POISON MUSHROOM CHUTE: Creates deadly fast zigzag descent
+			// Force centipede into zigzag pattern by alternating direction
+			g.dropHead(seg, g.config.PoisonDrop) // TRUE CHUTE EFFECT! Falls much faster
+			seg.Direction *= -1                  // Reverse direction
+
+			// Create tight zigzag by limiting horizontal movement
+			// The centipede will zigzag within a 3-character chute
+			hitPoisonMushroom = true
+			seg.Poisoned, seg.Scorpion = true, mush.Scorpion
+		} else {
+			g.dropHead(seg, 1)
+			seg.Poisoned, seg.Scorpion = false, false
+		}
+		seg.Direction *= -1
+	}
+
+	// Poison mushrooms cause centipede to drop faster in zigzag chute
+	if hitPoisonMushroom {
+		// Already handled above - centipede drops and zigzags
+	}
+}
+
+// MovePlayer moves the player a cell sideways. Mushrooms block the way;
+// moving into a centipede, flea or spider costs a life.
+func (g *Game) MovePlayer(dx int) {
+	newX := g.player.Pos.X + dx
+	if newX > 0 && newX < g.width-1 {
+		// Check mushroom collision
+		if to := (Position{X: newX, Y: g.player.Pos.Y}); g.mushroomAt(to) < 0 {
+			g.movePlayer(to)
+		}
+	}
+}
+
+// MovePlayerY moves the player a cell up or down within the player zone,
+// with the same collisions as MovePlayer
+func (g *Game) MovePlayerY(dy int) {
+	newY := g.player.Pos.Y + dy
+	// Allow movement in the player zone
+	if newY >= g.PlayerZoneTop() && newY <= g.PlayerZoneBottom() {
+		// Check mushroom collision
+		if to := (Position{X: g.player.Pos.X, Y: newY}); g.mushroomAt(to) < 0 {
+			g.movePlayer(to)
+		}
+	}
+}
+
+func (g *Game) Shoot() {
+	// UNLIMITED BULLETS - removed the limit!
+	y := g.player.Pos.Y - 1
+	g.bullets = append(g.bullets, Bullet{
+		Pos:    Position{X: g.player.Pos.X, Y: y},
+		Active: true,
+		fromY:  y,
+	})
+	g.grid.addBullet(len(g.bullets)-1, g.player.Pos)
+	g.stats.ShotsFired++
+}
+
+// loseLife takes a life for the death described by the caller, which only
+// fills in the cause and chute fields
+func (g *Game) loseLife(death LifeLost) {
+	// Several things can hit the player in the tick the last life goes
+	if g.gameOver {
+		return
+	}
+	g.lives--
+	death.Pos = g.player.Pos
+	death.Lives = g.lives
+	g.emit(death)
+	if g.lives <= 0 {
+		g.gameOver = true
+		g.emit(GameOver{Score: g.score, Level: g.level})
+	} else {
+		// Start respawn sequence
+		g.respawning = true
+		g.respawnTimer = g.config.RespawnTicks
+		// Reset player position
+		g.player.Pos.X = g.width / 2
+		g.player.Pos.Y = g.height - 2
+		g.player.from = g.player.Pos
+		// Clear bullets
+		g.bullets = g.bullets[:0]
+		g.grid.clearBullets()
+		// Spiders leave so the player isn't killed again on respawn
+		for i := range g.spiders {
+			g.retire(&g.spiders[i].Active, g.spiders[i].Pos)
+		}
+		// Regenerate all mushrooms to full health
+		g.regenerateMushrooms()
+	}
+}
+
+func (g *Game) regenerateMushrooms() {
+	// Restore all mushrooms to full health (4) and clear poison
+	for i := range g.mushrooms {
+		g.mushrooms[i].Health = 4
+		g.mushrooms[i].Poisoned = false // Reset poison status
+		g.mushrooms[i].Scorpion = false
+	}
+}
@@ -0,0 +1,167 @@
+package engine
+
+// grid indexes what occupies each board cell, so movement and collision
+// checks are lookups instead of scans over every mushroom, bullet and
+// enemy. The engine keeps it in step as things spawn, move and die.
+type grid struct {
+	width, height int
+	mushroom      []int32   // Index into g.mushrooms plus one, 0 when empty
+	segments      []uint16  // Centipede segments in the cell
+	enemies       []uint16  // Active flies, fleas, spiders and scorpions in the cell
+	columns       [][]int32 // Indexes into g.bullets of the bullets in each column
+}
+
+func newGrid(width, height int) grid {
+	return grid{
+		width:    width,
+		height:   height,
+		mushroom: make([]int32, width*height),
+		segments: make([]uint16, width*height),
+		enemies:  make([]uint16, width*height),
+		columns:  make([][]int32, width),
+	}
+}
+
+// cell returns p's index in the per-cell slices. Off-board positions have
+// none and are simply not indexed.
+func (gr *grid) cell(p Position) (int, bool) {
+	if p.X < 0 || p.X >= gr.width || p.Y < 0 || p.Y >= gr.height {
+		return 0, false
+	}
+	return p.Y*gr.width + p.X, true
+}
+
+func (gr *grid) addSegment(p Position) {
+	if i, ok := gr.cell(p); ok {
+		gr.segments[i]++
+	}
+}
+
+func (gr *grid) removeSegment(p Position) {
+	if i, ok := gr.cell(p); ok {
+		gr.segments[i]--
+	}
+}
+
+func (gr *grid) hasSegment(p Position) bool {
+	i, ok := gr.cell(p)
+	return ok && gr.segments[i] > 0
+}
+
+func (gr *grid) addEnemy(p Position) {
+	if i, ok := gr.cell(p); ok {
+		gr.enemies[i]++
+	}
+}
+
+func (gr *grid) removeEnemy(p Position) {
+	if i, ok := gr.cell(p); ok {
+		gr.enemies[i]--
+	}
+}
+
+func (gr *grid) hasEnemy(p Position) bool {
+	i, ok := gr.cell(p)
+	return ok && gr.enemies[i] > 0
+}
+
+// addBullet indexes bullet b. Bullets only ever fly straight up, so they
+// stay in their column for life.
+func (gr *grid) addBullet(b int, p Position) {
+	if p.X >= 0 && p.X < gr.width {
+		gr.columns[p.X] = append(gr.columns[p.X], int32(b))
+	}
+}
+
+// clearBullets forgets every bullet, for when g.bullets is emptied
+func (gr *grid) clearBullets() {
+	for x := range gr.columns {
+		gr.columns[x] = gr.columns[x][:0]
+	}
+}
+
+func (gr grid) clone() grid {
+	c := gr
+	c.mushroom = append([]int32(nil), gr.mushroom...)
+	c.segments = append([]uint16(nil), gr.segments...)
+	c.enemies = append([]uint16(nil), gr.enemies...)
+	c.columns = make([][]int32, len(gr.columns))
+	for x, col := range gr.columns {
+		c.columns[x] = append([]int32(nil), col...)
+	}
+	return c
+}
+
+// mushroomAt returns the index of the mushroom at p, or -1
+func (g *Game) mushroomAt(p Position) int {
+	i, ok := g.grid.cell(p)
+	if !ok {
+		return -1
+	}
+	return int(g.grid.mushroom[i]) - 1
+}
+
+// placeMushroom adds m unless its cell is taken or off the board, and
+// reports whether it did
+func (g *Game) placeMushroom(m Mushroom) bool {
+	i, ok := g.grid.cell(m.Pos)
+	if !ok || g.grid.mushroom[i] != 0 {
+		return false
+	}
+	g.mushrooms = append(g.mushrooms, m)
+	g.grid.mushroom[i] = int32(len(g.mushrooms))
+	return true
+}
+
+// removeMushroom deletes mushroom j. The last mushroom moves into its slot,
+// so removal is constant time but reorders g.mushrooms.
+func (g *Game) removeMushroom(j int) {
+	i, _ := g.grid.cell(g.mushrooms[j].Pos)
+	g.grid.mushroom[i] = 0
+	last := len(g.mushrooms) - 1
+	if j != last {
+		g.mushrooms[j] = g.mushrooms[last]
+		moved, _ := g.grid.cell(g.mushrooms[j].Pos)
+		g.grid.mushroom[moved] = int32(j + 1)
+	}
+	g.mushrooms = g.mushrooms[:last]
+}
+
+// bulletAt returns an active bullet whose path this tick covers p, or nil.
+// Spent bullets are dropped from the column index as it goes.
+func (g *Game) bulletAt(p Position) *Bullet {
+	if p.X < 0 || p.X >= g.grid.width {
+		return nil
+	}
+	col := g.grid.columns[p.X]
+	live := col[:0]
+	var hit *Bullet
+	for _, i := range col {
+		b := &g.bullets[i]
+		if !b.Active {
+			continue
+		}
+		live = append(live, i)
+		if hit == nil && b.Pos.Y <= p.Y && p.Y <= b.fromY {
+			hit = b
+		}
+	}
+	g.grid.columns[p.X] = live
+	return hit
+}
+
+// moveEnemy moves an active enemy standing at *pos to to
+func (g *Game) moveEnemy(pos *Position, to Position) {
+	g.grid.removeEnemy(*pos)
+	*pos = to
+	g.grid.addEnemy(to)
+}
+
+// retire deactivates the enemy at p and takes it off the grid. Already
+// inactive enemies are left alone, so it is safe to call twice.
+func (g *Game) retire(active *bool, p Position) {
+	if *active {
+		*active = false
+		g.grid.removeEnemy(p)
+	}
+}
@@ -0,0 +1,45 @@
+package engine
+
+// Action is a single player input
+type Action byte
+
+const (
+	ActionLeft  Action = 'L'
+	ActionRight Action = 'R'
+	ActionUp    Action = 'U'
+	ActionDown  Action = 'D'
+	ActionShoot Action = 'S'
+	ActionPause Action = 'P' // Recorded for replays, no effect on the game itself
+)
+
+// valid reports whether a is one of the actions above
+func (a Action) valid() bool {
+	switch a {
+	case ActionLeft, ActionRight, ActionUp, ActionDown, ActionShoot, ActionPause:
+		return true
+	}
+	return false
+}
+
+// Input is an action stamped with the tick it was applied before
+type Input struct {
+	Tick   int
+	Action Action
+}
+
+// Apply performs a player action and records it for replays
+func (g *Game) Apply(a Action) {
+	g.inputs = append(g.inputs, Input{Tick: g.tick, Action: a})
+	switch a {
+	case ActionLeft:
+		g.MovePlayer(-1)
+	case ActionRight:
+		g.MovePlayer(1)
+	case ActionUp:
+		g.MovePlayerY(-1)
+	case ActionDown:
+		g.MovePlayerY(1)
+	case ActionShoot:
+		g.Shoot()
+	}
+}
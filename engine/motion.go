@@ -0,0 +1,60 @@
+package engine
+
+import "math"
+
+// fixed is a fixed-point distance or speed. subCell units make one board
+// cell, so speeds can be fractions of a cell per tick.
+type fixed int32
+
+const subCell fixed = 256
+
+// toFixed converts a distance in cells to fixed point
+func toFixed(cells float64) fixed {
+	return fixed(math.Round(cells * float64(subCell)))
+}
+
+// motion carries an entity's progress through its current cell between
+// ticks. Positions stay whole cells for collisions and drawing; the
+// sub-cell remainder lives here until it adds up to another cell.
+type motion struct {
+	frac fixed
+}
+
+// advance moves one tick at speed and returns how many whole cells that
+// crossed. Callers step one cell at a time, so nothing is jumped over.
+func (m *motion) advance(speed fixed) int {
+	m.frac += speed
+	cells := m.frac / subCell
+	m.frac -= cells * subCell
+	return int(cells)
+}
+
+// speedTable is the config's speeds converted to fixed point once per game
+type speedTable struct {
+	bullet, fly, flea, fleaAccel, spider, scorpion fixed
+	centipede, centipedeLevel, centipedeMax        fixed
+}
+
+func newSpeedTable(c Config) speedTable {
+	return speedTable{
+		bullet:         toFixed(c.BulletSpeed),
+		fly:            toFixed(c.FlySpeed),
+		flea:           toFixed(c.FleaSpeed),
+		fleaAccel:      toFixed(c.FleaAccel),
+		spider:         toFixed(c.SpiderSpeed),
+		scorpion:       toFixed(c.ScorpionSpeed),
+		centipede:      toFixed(c.CentipedeSpeed),
+		centipedeLevel: toFixed(c.CentipedeSpeedLevel),
+		centipedeMax:   toFixed(c.CentipedeMaxSpeed),
+	}
+}
+
+// centipedeSpeed is the speed curve for centipedes: the level 1 speed plus
+// a step for every level after, up to the cap
+func (s speedTable) centipedeSpeed(level int) fixed {
+	speed := s.centipede + s.centipedeLevel*fixed(level-1)
+	if speed > s.centipedeMax {
+		speed = s.centipedeMax
+	}
+	return speed
+}
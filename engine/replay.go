@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Replay files start with a header naming the format and its version. The
+// version goes up whenever the simulation changes, since inputs recorded
+// under other rules play out differently.
+const (
+	replayMagic   = "centipede-replay"
+	replayVersion = 2
+)
+
+// replayHeader is the first line of every replay file
+var replayHeader = fmt.Sprintf("%s %d", replayMagic, replayVersion)
+
+// Replay is the seed, board size and config of a game plus every input
+// keyed by tick. Re-running the inputs on a fresh game reproduces it exactly.
+// File format (text, one record per line):
+//
+//	centipede-replay 2
+//	seed <seed>
+//	size <width> <height>
+//	config <key>=<value> ...
+//	<tick delta> <actions>
+//	...
+//
+// Input lines store the tick as a delta from the previous line, followed by
+// every action applied before that tick, e.g. "12 LS".
+type Replay struct {
+	Seed          int64
+	Width, Height int
+	Config        Config
+	Inputs        []Input
+}
+
+// Replay returns the recording of everything played so far
+func (g *Game) Replay() Replay {
+	return Replay{
+		Seed:   g.seed,
+		Width:  g.width,
+		Height: g.height,
+		Config: g.config,
+		Inputs: append([]Input(nil), g.inputs...),
+	}
+}
+
+// NewGame starts a fresh game set up the way the recorded one was
+func (r Replay) NewGame() *Game {
+	return NewGameWithConfig(r.Width, r.Height, r.Seed, r.Config)
+}
+
+// SaveReplay writes r to path, creating parent directories as needed
+func SaveReplay(path string, r Replay) error {
+	var sb strings.Builder
+	fmt.Fprintln(&sb, replayHeader)
+	fmt.Fprintf(&sb, "seed %d\n", r.Seed)
+	fmt.Fprintf(&sb, "size %d %d\n", r.Width, r.Height)
+	fmt.Fprintf(&sb, "config %s\n", strings.Join(r.Config.Pairs(), " "))
+
+	lastTick := 0
+	for i := 0; i < len(r.Inputs); {
+		tick := r.Inputs[i].Tick
+		var actions []byte
+		for ; i < len(r.Inputs) && r.Inputs[i].Tick == tick; i++ {
+			actions = append(actions, byte(r.Inputs[i].Action))
+		}
+		fmt.Fprintf(&sb, "%d %s\n", tick-lastTick, actions)
+		lastTick = tick
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+// LoadReplay reads a replay written by SaveReplay
+func LoadReplay(path string) (Replay, error) {
+	r := Replay{Config: DefaultConfig()}
+	f, err := os.Open(path)
+	if err != nil {
+		return r, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return r, fmt.Errorf("%s: not a centipede replay", path)
+	}
+	switch header := strings.Fields(scanner.Text()); {
+	case len(header) != 2 || header[0] != replayMagic:
+		return r, fmt.Errorf("%s: not a centipede replay", path)
+	case header[1] != strconv.Itoa(replayVersion):
+		return r, fmt.Errorf("%s: replay format version %s, but this build only plays version %d (replays recorded under other rules don't play back the same)",
+			path, header[1], replayVersion)
+	}
+
+	line := 1
+	tick := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var err error
+		switch fields[0] {
+		case "seed":
+			if len(fields) != 2 {
+				return r, fmt.Errorf("%s:%d: bad seed line", path, line)
+			}
+			r.Seed, err = strconv.ParseInt(fields[1], 10, 64)
+		case "size":
+			if len(fields) != 3 {
+				return r, fmt.Errorf("%s:%d: bad size line", path, line)
+			}
+			r.Width, err = strconv.Atoi(fields[1])
+			if err == nil {
+				r.Height, err = strconv.Atoi(fields[2])
+			}
+		case "config":
+			// Keys missing from older replays keep their defaults
+			for _, kv := range fields[1:] {
+				if err = r.Config.SetPair(kv); err != nil {
+					break
+				}
+			}
+		default:
+			if len(fields) != 2 {
+				return r, fmt.Errorf("%s:%d: bad input line", path, line)
+			}
+			var delta int
+			if delta, err = strconv.Atoi(fields[0]); err == nil && delta < 0 {
+				return r, fmt.Errorf("%s:%d: negative tick delta %d", path, line, delta)
+			}
+			tick += delta
+			for _, a := range []byte(fields[1]) {
+				if !Action(a).valid() {
+					return r, fmt.Errorf("%s:%d: unknown action %q", path, line, a)
+				}
+				r.Inputs = append(r.Inputs, Input{Tick: tick, Action: Action(a)})
+			}
+		}
+		if err != nil {
+			return r, fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return r, err
+	}
+	if r.Width <= 0 || r.Height <= 0 {
+		return r, fmt.Errorf("%s: missing board size", path)
+	}
+	if err := CheckSize(r.Width, r.Height); err != nil {
+		return r, fmt.Errorf("%s: %v", path, err)
+	}
+	return r, nil
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayRoundTrip(t *testing.T) {
+	g := NewGame(40, 24, 9)
+	for i := 0; i < 300 && !g.GameOver(); i++ {
+		g.Apply(ActionShoot)
+		if i%30 < 15 {
+			g.Apply(ActionLeft)
+		}
+		g.Step()
+	}
+	path := filepath.Join(t.TempDir(), "replay.txt")
+	if err := SaveReplay(path, g.Replay()); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again := r.NewGame()
+	inputs := r.Inputs
+	for again.Tick() < g.Tick() {
+		for len(inputs) > 0 && inputs[0].Tick == again.Tick() {
+			again.Apply(inputs[0].Action)
+			inputs = inputs[1:]
+		}
+		again.Step()
+	}
+	if again.Score() != g.Score() || again.Lives() != g.Lives() || again.Player() != g.Player() {
+		t.Errorf("replayed game ended at score %d, %d lives, player %v; want %d, %d, %v",
+			again.Score(), again.Lives(), again.Player(), g.Score(), g.Lives(), g.Player())
+	}
+}
+
+func TestLoadReplayRejects(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{"not a replay", "hello\n", "not a centipede replay"},
+		{"old version", "centipede-replay 1\nseed 1\nsize 50 28\n", "format version 1, but this build only plays version 2"},
+		{"no size", replayHeader + "\nseed 1\n", "missing board size"},
+		{"tiny board", replayHeader + "\nseed 1\nsize 10 5\n", "board must be from 30x20 to 100x40, got 10x5"},
+		{"huge board", replayHeader + "\nseed 1\nsize 100000 100000\n", "got 100000x100000"},
+		{"bad input", replayHeader + "\nseed 1\nsize 50 28\n3\n", "bad input line"},
+		{"negative delta", replayHeader + "\nseed 1\nsize 50 28\n3 L\n-2 R\n", "replay.txt:5: negative tick delta -2"},
+		{"unknown action", replayHeader + "\nseed 1\nsize 50 28\n3 LXS\n", "replay.txt:4: unknown action 'X'"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "replay.txt")
+		if err := os.WriteFile(path, []byte(tt.body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadReplay(path); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error %v, want it to mention %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package engine
+
+// Read-only views of the game state. Slices returned here are the game's own
+// storage: they are valid until the next Step or Apply and must not be
+// modified.
+
+func (g *Game) Width() int            { return g.width }
+func (g *Game) Height() int           { return g.height }
+func (g *Game) Config() Config        { return g.config }
+func (g *Game) Seed() int64           { return g.seed }
+func (g *Game) Tick() int             { return g.tick }
+func (g *Game) Score() int            { return g.score }
+func (g *Game) Lives() int            { return g.lives }
+func (g *Game) Level() int            { return g.level }
+func (g *Game) GameOver() bool        { return g.gameOver }
+func (g *Game) Won() bool             { return g.won }
+func (g *Game) Respawning() bool      { return g.respawning }
+func (g *Game) RespawnTimer() int     { return g.respawnTimer }
+func (g *Game) Player() Position      { return g.player.Pos }
+func (g *Game) LoneHeadsSpawned() int { return g.stats.LoneHeadsSpawned }
+
+func (g *Game) Centipedes() []Centipede { return g.centipedes }
+func (g *Game) Bullets() []Bullet       { return g.bullets }
+func (g *Game) Mushrooms() []Mushroom   { return g.mushrooms }
+func (g *Game) Flies() []Fly            { return g.flies }
+func (g *Game) Fleas() []Flea           { return g.fleas }
+func (g *Game) Spiders() []Spider       { return g.spiders }
+func (g *Game) Scorpions() []Scorpion   { return g.scorpions }
+func (g *Game) Explosions() []Explosion { return g.explosions }
@@ -0,0 +1,83 @@
+package engine
+
+import "testing"
+
+// benchGame plays a scripted shooter for warmup ticks so the board looks
+// like a game in progress. Dense games start with a field full of mushrooms,
+// like the late levels after fleas and dead segments have filled it in.
+func benchGame(b *testing.B, mushrooms, warmup int) *Game {
+	b.Helper()
+	c := DefaultConfig()
+	c.InitialMushrooms = mushrooms
+	c.StartingLives = 1000 // Keep the game going however the script plays
+	g := NewGameWithConfig(50, 28, 42, c)
+	for i := 0; i < warmup; i++ {
+		playScript(g, i)
+		g.Step()
+	}
+	return g
+}
+
+// playScript fires every tick and sweeps the player back and forth
+func playScript(g *Game, tick int) {
+	g.Apply(ActionShoot)
+	if tick%40 < 20 {
+		g.Apply(ActionLeft)
+	} else {
+		g.Apply(ActionRight)
+	}
+}
+
+// benchmarkStep measures one tick of play from a snapshot, restarting from
+// the snapshot every 500 ticks so the board stays about as busy
+func benchmarkStep(b *testing.B, mushrooms, warmup int) {
+	snapshot := benchGame(b, mushrooms, warmup)
+	g := snapshot.Clone()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%500 == 0 {
+			b.StopTimer()
+			g = snapshot.Clone()
+			b.StartTimer()
+		}
+		playScript(g, i)
+		g.Step()
+	}
+}
+
+func BenchmarkStepOpening(b *testing.B) { benchmarkStep(b, 25, 0) }
+func BenchmarkStepMidGame(b *testing.B) { benchmarkStep(b, 25, 2000) }
+func BenchmarkStepDense(b *testing.B)   { benchmarkStep(b, 400, 2000) }
+
+// BenchmarkMovePlayer is the player walking into and around mushrooms on a
+// dense board
+func BenchmarkMovePlayer(b *testing.B) {
+	g := benchGame(b, 400, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%40 < 20 {
+			g.MovePlayer(-1)
+		} else {
+			g.MovePlayer(1)
+		}
+		g.MovePlayerY(1 - 2*(i%2))
+	}
+}
+
+// BenchmarkLongSession plays 10,000 ticks of rapid fire per op. Spent
+// entities are compacted away each tick, so allocations and the slot counts
+// reported stay flat however long the session runs.
+func BenchmarkLongSession(b *testing.B) {
+	b.ReportAllocs()
+	var slots int
+	for i := 0; i < b.N; i++ {
+		g := benchGame(b, 25, 0)
+		for tick := 0; tick < 10000; tick++ {
+			playScript(g, tick)
+			g.Step()
+		}
+		slots = cap(g.bullets) + cap(g.flies) + cap(g.fleas) + cap(g.explosions)
+	}
+	b.ReportMetric(float64(slots), "slots")
+}
@@ -0,0 +1,113 @@
+package engine
+
+// Stats is the running telemetry for one game. Most of it is tallied from
+// the events Step emits, so it always agrees with what actually happened.
+type Stats struct {
+	// Kills by the player, by what was shot
+	HeadKills     int `json:"headKills"`
+	BodyKills     int `json:"bodyKills"`
+	FlyKills      int `json:"flyKills"`
+	FleaKills     int `json:"fleaKills"`
+	SpiderKills   int `json:"spiderKills"`
+	ScorpionKills int `json:"scorpionKills"`
+
+	ShotsFired int `json:"shotsFired"`
+	ShotsHit   int `json:"shotsHit"` // Bullets that hit an enemy or a mushroom
+
+	MushroomsDestroyed int `json:"mushroomsDestroyed"` // Shot down by the player
+	MushroomsCreated   int `json:"mushroomsCreated"`   // Left by dead segments and fleas
+	MushroomsPoisoned  int `json:"mushroomsPoisoned"`  // By flies and scorpions
+	MushroomsEaten     int `json:"mushroomsEaten"`     // By spiders
+
+	// Deaths by cause. Chute deaths are centipede and escape deaths where
+	// the killer had just come down a poison chute; scorpion chute deaths
+	// are the ones whose poison a scorpion laid.
+	DeathsByCentipede   int `json:"deathsByCentipede"`
+	DeathsByEscape      int `json:"deathsByEscape"`
+	DeathsByFlea        int `json:"deathsByFlea"`
+	DeathsBySpider      int `json:"deathsBySpider"`
+	ChuteDeaths         int `json:"chuteDeaths"`
+	ScorpionChuteDeaths int `json:"scorpionChuteDeaths"`
+
+	LevelTicks       []int `json:"levelTicks"` // Ticks taken to clear each level, in order
+	BonusLives       int   `json:"bonusLives"`
+	LoneHeadsSpawned int   `json:"loneHeadsSpawned"` // Heads that entered from the sides of the player zone
+}
+
+// SegmentKills is every centipede segment shot, heads included
+func (s Stats) SegmentKills() int {
+	return s.HeadKills + s.BodyKills
+}
+
+// Deaths is the number of lives lost to any cause
+func (s Stats) Deaths() int {
+	return s.DeathsByCentipede + s.DeathsByEscape + s.DeathsByFlea + s.DeathsBySpider
+}
+
+// Accuracy is the fraction of shots that hit something
+func (s Stats) Accuracy() float64 {
+	if s.ShotsFired == 0 {
+		return 0
+	}
+	return float64(s.ShotsHit) / float64(s.ShotsFired)
+}
+
+// record tallies an emitted event
+func (s *Stats) record(e Event) {
+	switch e := e.(type) {
+	case SegmentKilled:
+		if e.Head {
+			s.HeadKills++
+		} else {
+			s.BodyKills++
+		}
+		s.ShotsHit++
+	case FlyKilled:
+		s.FlyKills++
+		s.ShotsHit++
+	case FleaKilled:
+		s.FleaKills++
+		s.ShotsHit++
+	case SpiderKilled:
+		s.SpiderKills++
+		s.ShotsHit++
+	case ScorpionKilled:
+		s.ScorpionKills++
+		s.ShotsHit++
+	case MushroomHit:
+		if e.Destroyed {
+			s.MushroomsDestroyed++
+		}
+		s.ShotsHit++
+	case MushroomPoisoned:
+		s.MushroomsPoisoned++
+	case LifeLost:
+		switch e.Cause {
+		case CauseCentipede:
+			s.DeathsByCentipede++
+		case CauseEscape:
+			s.DeathsByEscape++
+		case CauseFlea:
+			s.DeathsByFlea++
+		case CauseSpider:
+			s.DeathsBySpider++
+		}
+		if e.Chute {
+			s.ChuteDeaths++
+			if e.Scorpion {
+				s.ScorpionChuteDeaths++
+			}
+		}
+	case LevelCleared:
+		s.LevelTicks = append(s.LevelTicks, e.Ticks)
+	case BonusLife:
+		s.BonusLives++
+	}
+}
+
+// Stats returns a copy of the game's telemetry so far
+func (g *Game) Stats() Stats {
+	s := g.stats
+	s.LevelTicks = append([]int(nil), g.stats.LevelTicks...)
+	return s
+}
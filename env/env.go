@@ -0,0 +1,193 @@
+// Package env exposes the Centipede engine as a gym-style reinforcement
+// learning environment: Reset starts a seeded game and returns an
+// observation, Step applies one action and returns the next observation
+// with a shaped reward. Serve speaks the same API as newline-delimited JSON
+// so agents can be trained from any language against the real engine.
+package env
+
+import (
+	"fmt"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Actions is the discrete action space. Index i in a step request means
+// Actions[i]. The plain moves (1-4) only move; each has a *_shoot twin
+// (6-9) that moves and fires in the same tick, and shoot (5) fires
+// standing still.
+var Actions = []struct {
+	Name   string
+	Inputs []engine.Action
+}{
+	{"noop", nil},
+	{"left", []engine.Action{engine.ActionLeft}},
+	{"right", []engine.Action{engine.ActionRight}},
+	{"up", []engine.Action{engine.ActionUp}},
+	{"down", []engine.Action{engine.ActionDown}},
+	{"shoot", []engine.Action{engine.ActionShoot}},
+	{"left_shoot", []engine.Action{engine.ActionLeft, engine.ActionShoot}},
+	{"right_shoot", []engine.Action{engine.ActionRight, engine.ActionShoot}},
+	{"up_shoot", []engine.Action{engine.ActionUp, engine.ActionShoot}},
+	{"down_shoot", []engine.Action{engine.ActionDown, engine.ActionShoot}},
+}
+
+// ActionIndex looks up an action by name
+func ActionIndex(name string) (int, bool) {
+	for i, a := range Actions {
+		if a.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Reward shapes the per-step reward:
+//
+//	reward = ScorePerPoint*Δscore + LifeLost*livesLost + Level*levelsCleared
+//	         + Step + GameOver (on the final step)
+type Reward struct {
+	ScorePerPoint float64 `json:"scorePerPoint"`
+	LifeLost      float64 `json:"lifeLost"` // Usually negative
+	Level         float64 `json:"level"`
+	Step          float64 `json:"step"`     // Survival bonus (or time penalty) per tick
+	GameOver      float64 `json:"gameOver"` // Usually negative
+}
+
+// DefaultReward rewards points and charges for deaths
+func DefaultReward() Reward {
+	return Reward{
+		ScorePerPoint: 0.01, // A body segment is worth 0.1, a head 1
+		LifeLost:      -10,
+		Level:         5,
+	}
+}
+
+// Options configures an episode
+type Options struct {
+	Width, Height int
+	Config        engine.Config
+	Reward        Reward
+	MaxTicks      int       // Episodes end (truncated) after this many ticks; 0 means no limit
+	Board         BoardMode // How the board is encoded in observations
+	Entities      bool      // Include the structured entity list
+}
+
+// DefaultOptions is the standard board and rules
+func DefaultOptions() Options {
+	return Options{
+		Width:    engine.DefaultWidth,
+		Height:   engine.DefaultHeight,
+		Config:   engine.DefaultConfig(),
+		Reward:   DefaultReward(),
+		MaxTicks: 20000,
+		Board:    BoardChannels,
+		Entities: true,
+	}
+}
+
+// Env is one environment instance. It is not safe for concurrent use; run
+// one per worker.
+type Env struct {
+	opts Options
+	game *engine.Game
+}
+
+// Info is the diagnostic data returned with every observation
+type Info struct {
+	Seed       int64 `json:"seed"`
+	Tick       int   `json:"tick"`
+	Score      int   `json:"score"`
+	ScoreDelta int   `json:"scoreDelta"`
+	Lives      int   `json:"lives"`
+	LivesLost  int   `json:"livesLost"` // This step
+	Level      int   `json:"level"`
+	Won        bool  `json:"won"`
+	Truncated  bool  `json:"truncated"` // Ended by MaxTicks rather than by the game
+}
+
+// StepResult is what Step returns
+type StepResult struct {
+	Observation Observation `json:"observation"`
+	Reward      float64     `json:"reward"`
+	Done        bool        `json:"done"`
+	Info        Info        `json:"info"`
+}
+
+func New(opts Options) *Env {
+	return &Env{opts: opts}
+}
+
+// Game exposes the running game, e.g. for saving a replay of an episode
+func (e *Env) Game() *engine.Game {
+	return e.game
+}
+
+// Reset starts a new episode. The same seed and actions give the same
+// episode every time.
+func (e *Env) Reset(seed int64) (Observation, Info) {
+	e.game = engine.NewGameWithConfig(e.opts.Width, e.opts.Height, seed, e.opts.Config)
+	return observe(e.game, e.opts), e.info(0, 0, false)
+}
+
+// Step applies action (an index into Actions) and advances one tick
+func (e *Env) Step(action int) (StepResult, error) {
+	if e.game == nil {
+		return StepResult{}, fmt.Errorf("step before reset")
+	}
+	if action < 0 || action >= len(Actions) {
+		return StepResult{}, fmt.Errorf("action %d out of range 0-%d", action, len(Actions)-1)
+	}
+	if e.done() {
+		return StepResult{}, fmt.Errorf("episode is over, reset first")
+	}
+
+	g := e.game
+	score, lives, level := g.Score(), g.Lives(), g.Level()
+	for _, a := range Actions[action].Inputs {
+		g.Apply(a)
+	}
+	g.Step()
+
+	scoreDelta := g.Score() - score
+	// Bonus lives can cancel out a death in the same tick; only count losses
+	livesLost := 0
+	if g.Lives() < lives {
+		livesLost = lives - g.Lives()
+	}
+	r := e.opts.Reward
+	reward := r.ScorePerPoint*float64(scoreDelta) +
+		r.LifeLost*float64(livesLost) +
+		r.Level*float64(g.Level()-level) +
+		r.Step
+	if g.GameOver() {
+		reward += r.GameOver
+	}
+
+	truncated := !g.GameOver() && !g.Won() && e.opts.MaxTicks > 0 && g.Tick() >= e.opts.MaxTicks
+	return StepResult{
+		Observation: observe(g, e.opts),
+		Reward:      reward,
+		Done:        e.done(),
+		Info:        e.info(scoreDelta, livesLost, truncated),
+	}, nil
+}
+
+func (e *Env) done() bool {
+	g := e.game
+	return g.GameOver() || g.Won() || (e.opts.MaxTicks > 0 && g.Tick() >= e.opts.MaxTicks)
+}
+
+func (e *Env) info(scoreDelta, livesLost int, truncated bool) Info {
+	g := e.game
+	return Info{
+		Seed:       g.Seed(),
+		Tick:       g.Tick(),
+		Score:      g.Score(),
+		ScoreDelta: scoreDelta,
+		Lives:      g.Lives(),
+		LivesLost:  livesLost,
+		Level:      g.Level(),
+		Won:        g.Won(),
+		Truncated:  truncated,
+	}
+}
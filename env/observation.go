@@ -0,0 +1,182 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// BoardMode selects how GetBoard is encoded in observations
+type BoardMode string
+
+const (
+	BoardChannels BoardMode = "channels" // [channel][y][x], one plane per cell kind
+	BoardCodes    BoardMode = "codes"    // [y][x] holding channel index + 1, 0 for empty
+	BoardNone     BoardMode = "none"     // No board, entities only
+)
+
+// Channels names the board planes, in order
+var Channels = []string{
+	"player", "head", "body", "mushroom", "poison", "bullet",
+	"fly", "wing", "flea", "spider", "scorpion", "explosion",
+}
+
+// cellChannel maps a board cell to its channel and value. Mushroom cells
+// hold their health (1-4); everything else is 1. Empty cells report ok
+// false.
+func cellChannel(c engine.Cell) (channel, value int, ok bool) {
+	switch c.Kind {
+	case engine.KindPlayer:
+		return 0, 1, true
+	case engine.KindSegment:
+		if c.Head {
+			return 1, 1, true
+		}
+		return 2, 1, true
+	case engine.KindMushroom:
+		if c.Poisoned {
+			return 4, 1, true
+		}
+		return 3, c.Health, true
+	case engine.KindBullet:
+		return 5, 1, true
+	case engine.KindFly:
+		return 6, 1, true
+	case engine.KindWing:
+		return 7, 1, true
+	case engine.KindFlea:
+		return 8, 1, true
+	case engine.KindSpider:
+		return 9, 1, true
+	case engine.KindScorpion:
+		return 10, 1, true
+	case engine.KindExplosion:
+		return 11, 1, true
+	}
+	return 0, 0, false
+}
+
+// Entity is one live object in the structured observation
+type Entity struct {
+	Kind      string `json:"kind"` // head, body, mushroom, bullet, fly, flea, spider, scorpion
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Direction int    `json:"direction,omitempty"` // -1 left, 1 right, for movers that have one
+	Health    int    `json:"health,omitempty"`    // Mushrooms
+	Poisoned  bool   `json:"poisoned,omitempty"`  // Mushrooms
+	Chain     *int   `json:"chain,omitempty"`     // Centipede index, for heads and bodies; chain 0 is sent too
+}
+
+// Observation is the agent's view of one tick
+type Observation struct {
+	Tick       int       `json:"tick"`
+	Score      int       `json:"score"`
+	Lives      int       `json:"lives"`
+	Level      int       `json:"level"`
+	Player     [2]int    `json:"player"` // x, y
+	Respawning bool      `json:"respawning"`
+	Channels   [][][]int `json:"channels,omitempty"`
+	Codes      [][]int   `json:"codes,omitempty"`
+	Entities   []Entity  `json:"entities,omitempty"`
+}
+
+// ParseBoardMode validates a board mode name
+func ParseBoardMode(s string) (BoardMode, error) {
+	switch m := BoardMode(s); m {
+	case BoardChannels, BoardCodes, BoardNone:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown board mode %q (want channels, codes or none)", s)
+}
+
+func observe(g *engine.Game, opts Options) Observation {
+	p := g.Player()
+	obs := Observation{
+		Tick:       g.Tick(),
+		Score:      g.Score(),
+		Lives:      g.Lives(),
+		Level:      g.Level(),
+		Player:     [2]int{p.X, p.Y},
+		Respawning: g.Respawning(),
+	}
+
+	switch opts.Board {
+	case BoardChannels:
+		obs.Channels = make([][][]int, len(Channels))
+		for c := range obs.Channels {
+			obs.Channels[c] = grid(g.Width(), g.Height())
+		}
+		for y, row := range g.GetBoard() {
+			for x, cell := range row {
+				if channel, value, ok := cellChannel(cell); ok {
+					obs.Channels[channel][y][x] = value
+				}
+			}
+		}
+	case BoardCodes:
+		obs.Codes = grid(g.Width(), g.Height())
+		for y, row := range g.GetBoard() {
+			for x, cell := range row {
+				if channel, _, ok := cellChannel(cell); ok {
+					obs.Codes[y][x] = channel + 1
+				}
+			}
+		}
+	}
+
+	if opts.Entities {
+		obs.Entities = entities(g)
+	}
+	return obs
+}
+
+func grid(width, height int) [][]int {
+	rows := make([][]int, height)
+	for y := range rows {
+		rows[y] = make([]int, width)
+	}
+	return rows
+}
+
+func entities(g *engine.Game) []Entity {
+	list := []Entity{}
+	for ci, c := range g.Centipedes() {
+		chain := ci
+		for si, seg := range c.Segments {
+			kind := "body"
+			if si == 0 {
+				kind = "head"
+			}
+			list = append(list, Entity{Kind: kind, X: seg.Pos.X, Y: seg.Pos.Y, Direction: seg.Direction, Chain: &chain})
+		}
+	}
+	for _, m := range g.Mushrooms() {
+		list = append(list, Entity{Kind: "mushroom", X: m.Pos.X, Y: m.Pos.Y, Health: m.Health, Poisoned: m.Poisoned})
+	}
+	for _, b := range g.Bullets() {
+		if b.Active {
+			list = append(list, Entity{Kind: "bullet", X: b.Pos.X, Y: b.Pos.Y})
+		}
+	}
+	for _, f := range g.Flies() {
+		if f.Active {
+			list = append(list, Entity{Kind: "fly", X: f.Pos.X, Y: f.Pos.Y, Direction: f.Direction})
+		}
+	}
+	for _, f := range g.Fleas() {
+		if f.Active {
+			list = append(list, Entity{Kind: "flea", X: f.Pos.X, Y: f.Pos.Y})
+		}
+	}
+	for _, s := range g.Spiders() {
+		if s.Active {
+			list = append(list, Entity{Kind: "spider", X: s.Pos.X, Y: s.Pos.Y, Direction: s.DX})
+		}
+	}
+	for _, s := range g.Scorpions() {
+		if s.Active {
+			list = append(list, Entity{Kind: "scorpion", X: s.Pos.X, Y: s.Pos.Y, Direction: s.Direction})
+		}
+	}
+	return list
+}
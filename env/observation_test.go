@@ -0,0 +1,101 @@
+package env
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+func TestEntityJSON(t *testing.T) {
+	zero, two := 0, 2
+	tests := []struct {
+		name string
+		e    Entity
+		want string
+	}{
+		{"first chain", Entity{Kind: "head", X: 3, Y: 0, Direction: 1, Chain: &zero},
+			`{"kind":"head","x":3,"y":0,"direction":1,"chain":0}`},
+		{"later chain", Entity{Kind: "body", X: 2, Y: 0, Direction: -1, Chain: &two},
+			`{"kind":"body","x":2,"y":0,"direction":-1,"chain":2}`},
+		{"no chain", Entity{Kind: "mushroom", X: 5, Y: 9, Health: 4, Poisoned: true},
+			`{"kind":"mushroom","x":5,"y":9,"health":4,"poisoned":true}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, data, tt.want)
+		}
+	}
+}
+
+func TestObserveEncodings(t *testing.T) {
+	g := engine.NewGame(engine.DefaultWidth, engine.DefaultHeight, 3)
+	for range 40 {
+		g.Apply(engine.ActionShoot)
+		g.Step()
+	}
+	opts := DefaultOptions()
+	channels := observe(g, opts)
+	opts.Board = BoardCodes
+	codes := observe(g, opts)
+	opts.Board, opts.Entities = BoardNone, false
+	bare := observe(g, opts)
+
+	if len(channels.Channels) != len(Channels) || channels.Codes != nil {
+		t.Fatalf("channels mode sent %d planes, codes %v", len(channels.Channels), channels.Codes != nil)
+	}
+	if bare.Channels != nil || bare.Codes != nil || bare.Entities != nil {
+		t.Error("none mode sent a board or entities")
+	}
+
+	// Each occupied cell is in exactly one plane, and its code names that plane
+	for y, row := range codes.Codes {
+		for x, code := range row {
+			lit := 0
+			for c, plane := range channels.Channels {
+				if plane[y][x] != 0 {
+					lit++
+					if code != c+1 {
+						t.Errorf("(%d,%d): code %d, but lit in %s", x, y, code, Channels[c])
+					}
+				}
+			}
+			if lit > 1 || lit == 0 && code != 0 {
+				t.Errorf("(%d,%d): code %d with %d planes lit", x, y, code, lit)
+			}
+		}
+	}
+
+	p := g.Player()
+	if channels.Channels[0][p.Y][p.X] != 1 || channels.Player != [2]int{p.X, p.Y} {
+		t.Errorf("player at %v not in the player plane", p)
+	}
+	for _, m := range g.Mushrooms() {
+		if m.Poisoned {
+			continue
+		}
+		if got := channels.Channels[3][m.Pos.Y][m.Pos.X]; got != 0 && got != m.Health {
+			t.Errorf("mushroom at %v reads %d, want its health %d", m.Pos, got, m.Health)
+		}
+	}
+
+	segments := 0
+	for _, c := range g.Centipedes() {
+		segments += len(c.Segments)
+	}
+	data, err := json.Marshal(channels.Entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), `"chain":`); n != segments {
+		t.Errorf("%d entities carry a chain, want one per segment (%d)", n, segments)
+	}
+	if !strings.Contains(string(data), `"chain":0`) {
+		t.Error("the first centipede's segments don't report chain 0")
+	}
+}
@@ -0,0 +1,191 @@
+package env
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/michaellavery-grp/centipede/engine"
+)
+
+// Wire protocol: one JSON object per line in each direction.
+//
+//	{"cmd":"spec"}
+//	{"cmd":"reset","seed":42,"config":{"startingLives":4},"reward":{"lifeLost":-50},
+//	 "maxTicks":5000,"board":"codes","entities":false,"width":50,"height":28}
+//	{"cmd":"step","action":7}            (or "action":"right_shoot")
+//	{"cmd":"close"}
+//
+// reset answers {"observation":...,"info":...}, step answers
+// {"observation":...,"reward":...,"done":...,"info":...}, and a bad request
+// answers {"error":"..."} without ending the session. Every reset field is
+// optional: unset fields take their defaults, and reward fields not given
+// keep their default weights.
+
+type request struct {
+	Cmd      string                     `json:"cmd"`
+	Seed     *int64                     `json:"seed"`
+	Width    int                        `json:"width"`
+	Height   int                        `json:"height"`
+	Config   map[string]json.RawMessage `json:"config"`
+	Reward   json.RawMessage            `json:"reward"`
+	MaxTicks *int                       `json:"maxTicks"`
+	Board    string                     `json:"board"`
+	Entities *bool                      `json:"entities"`
+	Action   json.RawMessage            `json:"action"`
+}
+
+type resetResponse struct {
+	Observation Observation `json:"observation"`
+	Info        Info        `json:"info"`
+}
+
+type specResponse struct {
+	Actions    []string `json:"actions"`
+	Channels   []string `json:"channels"`
+	ConfigKeys []string `json:"configKeys"`
+	Reward     Reward   `json:"defaultReward"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Serve runs the protocol until r is exhausted or a close command arrives
+func Serve(r io.Reader, w io.Writer) error {
+	in := bufio.NewScanner(r)
+	in.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	out := json.NewEncoder(w)
+	var e *Env
+
+	for in.Scan() {
+		if len(in.Bytes()) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil {
+			if err := out.Encode(errorResponse{"bad request: " + err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var resp any
+		switch req.Cmd {
+		case "spec":
+			resp = spec()
+		case "reset":
+			opts, err := req.options()
+			if err != nil {
+				resp = errorResponse{err.Error()}
+				break
+			}
+			seed := time.Now().UnixNano()
+			if req.Seed != nil {
+				seed = *req.Seed
+			}
+			e = New(opts)
+			obs, info := e.Reset(seed)
+			resp = resetResponse{obs, info}
+		case "step":
+			if e == nil {
+				resp = errorResponse{"step before reset"}
+				break
+			}
+			action, err := parseAction(req.Action)
+			if err != nil {
+				resp = errorResponse{err.Error()}
+				break
+			}
+			result, err := e.Step(action)
+			if err != nil {
+				resp = errorResponse{err.Error()}
+				break
+			}
+			resp = result
+		case "close":
+			return nil
+		default:
+			resp = errorResponse{fmt.Sprintf("unknown cmd %q", req.Cmd)}
+		}
+		if err := out.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return in.Err()
+}
+
+func spec() specResponse {
+	s := specResponse{
+		Channels:   Channels,
+		ConfigKeys: engine.ConfigKeys(),
+		Reward:     DefaultReward(),
+	}
+	for _, a := range Actions {
+		s.Actions = append(s.Actions, a.Name)
+	}
+	return s
+}
+
+// options builds episode options from a reset request
+func (req request) options() (Options, error) {
+	opts := DefaultOptions()
+	if req.Width > 0 {
+		opts.Width = req.Width
+	}
+	if req.Height > 0 {
+		opts.Height = req.Height
+	}
+	if err := engine.CheckSize(opts.Width, opts.Height); err != nil {
+		return Options{}, err
+	}
+	for key, raw := range req.Config {
+		// Accept JSON numbers and bools as well as strings
+		value := string(raw)
+		if s, err := strconv.Unquote(value); err == nil {
+			value = s
+		}
+		if err := opts.Config.Set(key, value); err != nil {
+			return Options{}, err
+		}
+	}
+	if len(req.Reward) > 0 {
+		if err := json.Unmarshal(req.Reward, &opts.Reward); err != nil {
+			return Options{}, fmt.Errorf("bad reward: %w", err)
+		}
+	}
+	if req.MaxTicks != nil {
+		opts.MaxTicks = *req.MaxTicks
+	}
+	if req.Board != "" {
+		mode, err := ParseBoardMode(req.Board)
+		if err != nil {
+			return Options{}, err
+		}
+		opts.Board = mode
+	}
+	if req.Entities != nil {
+		opts.Entities = *req.Entities
+	}
+	return opts, nil
+}
+
+// parseAction accepts an index into Actions or an action name
+func parseAction(raw json.RawMessage) (int, error) {
+	var index int
+	if err := json.Unmarshal(raw, &index); err == nil {
+		return index, nil
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return 0, fmt.Errorf("action must be an index or a name")
+	}
+	index, ok := ActionIndex(name)
+	if !ok {
+		return 0, fmt.Errorf("unknown action %q", name)
+	}
+	return index, nil
+}
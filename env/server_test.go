@@ -0,0 +1,170 @@
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// serve runs a session over the given request lines and returns one decoded
+// response per answered request
+func serve(t *testing.T, lines ...string) []map[string]json.RawMessage {
+	t.Helper()
+	var out bytes.Buffer
+	if err := Serve(strings.NewReader(strings.Join(lines, "\n")), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var resps []map[string]json.RawMessage
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]json.RawMessage
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("bad response: %v", err)
+		}
+		resps = append(resps, resp)
+	}
+	return resps
+}
+
+func decode[T any](t *testing.T, raw json.RawMessage) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		t.Fatalf("decoding %s: %v", raw, err)
+	}
+	return v
+}
+
+func wantError(t *testing.T, resp map[string]json.RawMessage, substr string) {
+	t.Helper()
+	msg := decode[string](t, resp["error"])
+	if !strings.Contains(msg, substr) {
+		t.Errorf("error %q, want it to mention %q", msg, substr)
+	}
+}
+
+func TestServeSession(t *testing.T) {
+	resps := serve(t,
+		`{"cmd":"spec"}`,
+		`{"cmd":"step","action":0}`,
+		`{"cmd":"reset","seed":42,"width":40,"height":24,"config":{"startingLives":4},`+
+			`"reward":{"step":0.5},"maxTicks":3,"board":"codes","entities":false}`,
+		`{"cmd":"step","action":7}`,
+		``,
+		`{"cmd":"step","action":"left_shoot"}`,
+		`{"cmd":"step","action":0}`,
+		`{"cmd":"step","action":0}`,
+		`not json`,
+		`{"cmd":"fly"}`,
+		`{"cmd":"close"}`,
+		`{"cmd":"spec"}`,
+	)
+	if len(resps) != 9 {
+		t.Fatalf("%d responses, want 9 (blank lines skipped, nothing after close)", len(resps))
+	}
+
+	if r := decode[Reward](t, resps[0]["defaultReward"]); r != DefaultReward() {
+		t.Errorf("spec default reward = %+v", r)
+	}
+	if actions := decode[[]string](t, resps[0]["actions"]); len(actions) != len(Actions) || actions[7] != "right_shoot" {
+		t.Errorf("spec actions = %v", actions)
+	}
+	wantError(t, resps[1], "step before reset")
+
+	obs := decode[Observation](t, resps[2]["observation"])
+	info := decode[Info](t, resps[2]["info"])
+	if info.Seed != 42 || info.Lives != 4 || info.Tick != 0 {
+		t.Errorf("reset info = %+v, want seed 42, 4 lives, tick 0", info)
+	}
+	if len(obs.Codes) != 24 || len(obs.Codes[0]) != 40 {
+		t.Errorf("codes are %dx%d, want 40x24", len(obs.Codes[0]), len(obs.Codes))
+	}
+	if obs.Channels != nil || obs.Entities != nil {
+		t.Error("reset sent channels or entities it wasn't asked for")
+	}
+
+	for i, resp := range resps[3:6] {
+		step := decode[StepResult](t, mustMarshal(t, resp))
+		if step.Info.Tick != i+1 {
+			t.Errorf("step %d tick = %d", i+1, step.Info.Tick)
+		}
+		if step.Reward < 0.5 {
+			t.Errorf("step %d reward = %g, want the 0.5 step bonus at least", i+1, step.Reward)
+		}
+		if last := i == 2; step.Done != last || step.Info.Truncated != last {
+			t.Errorf("step %d done, truncated = %v, %v, want %v", i+1, step.Done, step.Info.Truncated, last)
+		}
+	}
+	wantError(t, resps[6], "episode is over")
+	wantError(t, resps[7], "bad request")
+	wantError(t, resps[8], `unknown cmd "fly"`)
+}
+
+// The server plays the same episode as the Env it wraps
+func TestServeMatchesEnv(t *testing.T) {
+	resps := serve(t,
+		`{"cmd":"reset","seed":7}`,
+		`{"cmd":"step","action":"up_shoot"}`,
+		`{"cmd":"step","action":2}`,
+	)
+
+	e := New(DefaultOptions())
+	obs, info := e.Reset(7)
+	want := []any{resetResponse{obs, info}}
+	for _, action := range []int{8, 2} {
+		result, err := e.Step(action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, result)
+	}
+
+	for i, resp := range resps {
+		got := decode[any](t, mustMarshal(t, resp))
+		expected := decode[any](t, mustMarshal(t, want[i]))
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("response %d differs from Env", i)
+		}
+	}
+}
+
+func TestServeBadOptions(t *testing.T) {
+	resps := serve(t,
+		`{"cmd":"reset","width":10}`,
+		`{"cmd":"reset","width":100000,"height":100000}`,
+		`{"cmd":"reset","config":{"noSuchKey":1}}`,
+		`{"cmd":"reset","board":"pixels"}`,
+		`{"cmd":"reset","reward":{"step":"lots"}}`,
+		`{"cmd":"reset","seed":1}`,
+		`{"cmd":"step","action":99}`,
+		`{"cmd":"step","action":"jump"}`,
+		`{"cmd":"step","action":true}`,
+	)
+	if len(resps) != 9 {
+		t.Fatalf("%d responses, want 9", len(resps))
+	}
+	wantError(t, resps[0], "board must be from 30x20 to 100x40")
+	wantError(t, resps[1], "got 100000x100000")
+	if _, ok := resps[2]["error"]; !ok {
+		t.Error("unknown config key accepted")
+	}
+	wantError(t, resps[3], "unknown board mode")
+	wantError(t, resps[4], "bad reward")
+	if _, ok := resps[5]["observation"]; !ok {
+		t.Error("reset failed after bad resets")
+	}
+	wantError(t, resps[6], "out of range")
+	wantError(t, resps[7], `unknown action "jump"`)
+	wantError(t, resps[8], "index or a name")
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}